@@ -0,0 +1,134 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// CheckAliases makes Free verify that no live value copied out by Detach
+// still points into the arena being freed. It applies to debug-mode arenas
+// (see Debug) created while it is set; like Debug, arenas capture it when
+// they are created.
+//
+// Detach copies are shallow, so a detached struct whose slice, string, or
+// pointer fields still refer to arena memory dangles once that arena is
+// freed. The check also spans arenas: in a function juggling two arenas,
+// freeing the wrong one while a copy detached from the other still points
+// into it is reported at the Free, instead of as a confusing panic or
+// corrupted data later. Free panics with a FreeWhileAliased ArenaError and
+// leaves the arena allocated.
+//
+// The check scans every live detached copy against every allocation recorded
+// by the arena, so each Free costs O(allocations × detached pointer fields).
+// Enable it in tests, not in production.
+//
+//	safearena.Debug = true
+//	safearena.CheckAliases = true
+var CheckAliases bool
+
+// detachedCopies holds the Detach records of arenas created with
+// CheckAliases, so Free can scan copies detached from any arena
+var detachedCopies struct {
+	mu   sync.Mutex
+	recs []*detachRecord
+}
+
+// registerDetached adds rec to the copies scanned by checkAliases
+func registerDetached(rec *detachRecord) {
+	detachedCopies.mu.Lock()
+	detachedCopies.recs = append(detachedCopies.recs, rec)
+	detachedCopies.mu.Unlock()
+}
+
+// checkAliases panics if a live detached copy points into memory recorded by
+// the arena, reporting the stack from skip frames up. Records of copies that
+// are no longer live are dropped.
+func (a *Arena) checkAliases(skip int) {
+	detachedCopies.mu.Lock()
+	var recs []*detachRecord
+	live := detachedCopies.recs[:0]
+	for _, rec := range detachedCopies.recs {
+		if rec.alive() {
+			live = append(live, rec)
+			recs = append(recs, rec)
+		}
+	}
+	clear(detachedCopies.recs[len(live):])
+	detachedCopies.recs = live
+	detachedCopies.mu.Unlock()
+
+	floor := a.floor.Load()
+	for _, rec := range recs {
+		ptr := rec.value()
+		if ptr == nil {
+			continue
+		}
+		var target *allocRecord
+		walkPointers(reflect.NewAt(rec.typ, ptr).Elem(), func(addr uintptr) bool {
+			target = a.debug.find(addr, floor)
+			return target == nil
+		})
+		if target != nil {
+			stack := captureStack(skip)
+			what := fmt.Sprintf("free while aliased: %s detached at %s points into this arena",
+				rec.typ, siteString(rec.site))
+			panic(errorWithSites(a, what, stack, target.site, FreeWhileAliased))
+		}
+	}
+}
+
+// find returns the record of the allocation from generation floor or later
+// that contains addr, or nil
+func (d *debugState) find(addr uintptr, floor uint64) *allocRecord {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.containing(addr, floor)
+}
+
+// containing is find for callers that hold d.mu. An allocation starting at
+// addr is found without scanning.
+func (d *debugState) containing(addr uintptr, floor uint64) *allocRecord {
+	if rec := d.allocs[addr]; rec != nil && rec.gen >= floor {
+		return rec
+	}
+	for start, rec := range d.allocs {
+		if rec.gen >= floor && addr >= start && addr-start < rec.size {
+			return rec
+		}
+	}
+	return nil
+}
+
+// walkPointers calls fn with the address held by each pointer, slice, and
+// string in v, without following them, until fn returns false. It reports
+// whether the walk completed.
+func walkPointers(v reflect.Value, fn func(addr uintptr) bool) bool {
+	if !hasPointers(v.Type()) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer:
+		return v.IsNil() || fn(v.Pointer())
+	case reflect.Slice:
+		return v.Cap() == 0 || fn(v.Pointer())
+	case reflect.String:
+		return v.Len() == 0 || fn(uintptr(unsafe.Pointer(unsafe.StringData(v.String()))))
+	case reflect.Interface:
+		return v.IsNil() || walkPointers(v.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !walkPointers(v.Field(i), fn) {
+				return false
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !walkPointers(v.Index(i), fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package safearena
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func enableAliasCheck(t *testing.T) {
+	t.Helper()
+	enableDebug(t)
+	CheckAliases = true
+	t.Cleanup(func() { CheckAliases = false })
+}
+
+type aliasView struct {
+	Name string
+	Data []byte
+}
+
+func TestCheckAliasesWrongArena(t *testing.T) {
+	enableAliasCheck(t)
+	buffers, views := New(), New()
+	defer views.Free()
+
+	data := AllocSlice[byte](buffers, 64)
+	view := Detach(Alloc(views, aliasView{Name: "frame", Data: data.Get()}))
+
+	func() {
+		defer func() {
+			r := recover()
+			var ae *ArenaError
+			if err, ok := r.(error); !ok || !errors.As(err, &ae) || ae.Kind != FreeWhileAliased {
+				t.Fatalf("expected FreeWhileAliased panic, got %v", r)
+			}
+			msg := ae.Error()
+			if !strings.Contains(msg, "safearena.aliasView detached at") || !strings.Contains(msg, "alias_test.go") {
+				t.Errorf("expected the detached type and site, got %q", msg)
+			}
+			if ae.AllocStack == nil {
+				t.Error("expected the aliased allocation site")
+			}
+		}()
+		buffers.Free() // Wrong arena: view still points into it
+	}()
+	if buffers.IsFreed() {
+		t.Error("expected the arena to stay allocated after the failed Free")
+	}
+
+	view.Data = nil
+	buffers.Free()
+	runtime.KeepAlive(view)
+}
+
+func TestCheckAliasesNoAlias(t *testing.T) {
+	enableAliasCheck(t)
+	a := New()
+
+	heapData := make([]byte, 8)
+	view := Detach(Alloc(a, aliasView{Name: "heap", Data: heapData}))
+	if !a.TryFree() {
+		t.Error("expected Free to succeed when detached copies only reference the heap")
+	}
+	runtime.KeepAlive(view)
+}
+
+func TestCheckAliasesOff(t *testing.T) {
+	enableDebug(t)
+	a := New()
+
+	data := AllocSlice[byte](a, 8)
+	view := Detach(Alloc(a, aliasView{Data: data.Get()}))
+	a.Free() // Not checked without CheckAliases
+	runtime.KeepAlive(view)
+}
@@ -0,0 +1,127 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// AllocSliceAligned allocates a zeroed slice of size elements in the arena
+// whose first element is aligned to align bytes, for SIMD loads or
+// cache-line-sized blocks. The arena array is over-allocated and the returned
+// Slice is the aligned window into it.
+//
+// Panics if align is not a power of two, if no element of the array can be
+// aligned (possible only when align is smaller than T's size and not a
+// multiple of it), or if the arena has already been freed.
+//
+// Example:
+//
+//	v := safearena.AllocSliceAligned[float32](a, 1024, 64) // cache-line aligned
+//	ptr, _ := v.Data()
+//	// uintptr(ptr)%64 == 0
+func AllocSliceAligned[T any](a *Arena, size, align int) Slice[T] {
+	if a.debug != nil {
+		defer recordTiming(opAllocSlice, time.Now())
+	}
+	if align <= 0 || align&(align-1) != 0 {
+		panic(fmt.Sprintf("safearena: alignment %d is not a power of two", align))
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	elemSize := int(unsafe.Sizeof(*new(T)))
+	if elemSize == 0 {
+		return AllocSlice[T](a, size)
+	}
+
+	// Element addresses repeat modulo align every period elements, so that
+	// many extra elements are enough to reach an aligned one if any is.
+	period := align / gcd(elemSize, align)
+	total := size + period - 1
+	if !a.charge(int64(total) * int64(elemSize)) {
+		stack := captureStack(2)
+		panic(a.chargeError(stack))
+	}
+
+	backing := backingMakeSlice[T](a.inner, total, total)
+	base := uintptr(unsafe.Pointer(unsafe.SliceData(backing)))
+	offset := -1
+	for k := 0; k < period; k++ {
+		if (base+uintptr(k*elemSize))%uintptr(align) == 0 {
+			offset = k
+			break
+		}
+	}
+	if offset < 0 {
+		panic(fmt.Sprintf("safearena: cannot align %d-byte elements to %d bytes", elemSize, align))
+	}
+
+	slice := backing[offset : offset+size : offset+size]
+	if a.debug != nil {
+		a.recordSliceAlloc(unsafe.Pointer(unsafe.SliceData(slice)), captureStack(2),
+			reflect.TypeFor[[]T](), uintptr(size*elemSize))
+	}
+
+	return Slice[T]{
+		slice: slice,
+		arena: a,
+		gen:   a.gen.Load(),
+	}
+}
+
+// gcd returns the greatest common divisor of two positive integers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ReinterpretSlice views the memory of s as elements of another type without
+// copying, such as a Slice[byte] filled by a reader viewed as a
+// Slice[uint32] for bulk processing. The result shares s's memory and arena,
+// so use after free, reset, or TakeBytes still panics through either view.
+//
+// This is unsafe in the way unsafe.Slice is: the bytes are interpreted in
+// machine byte order, and writes through one view are visible through the
+// other. To keep the garbage collector sound, neither type may contain
+// pointers (including strings, slices, and interfaces). Use
+// AllocSliceAligned to obtain a buffer suitably aligned for To.
+//
+// Panics if either type contains pointers or is zero-sized, if the length of
+// s in bytes is not a multiple of To's size, if the memory is not aligned for
+// To, or if the arena has been freed or reset.
+//
+// Example:
+//
+//	buf := safearena.AllocSliceAligned[byte](a, 4096, 4)
+//	io.ReadFull(r, buf.Get())
+//	words := safearena.ReinterpretSlice[uint32](buf) // 1024 words
+func ReinterpretSlice[To, From any](s Slice[From]) Slice[To] {
+	data := s.dataPtr(3)
+
+	from, to := reflect.TypeFor[From](), reflect.TypeFor[To]()
+	if hasPointers(from) || hasPointers(to) {
+		panic(fmt.Sprintf("safearena: cannot reinterpret []%s as []%s: pointer-bearing types are not allowed", from, to))
+	}
+	if from.Size() == 0 || to.Size() == 0 {
+		panic(fmt.Sprintf("safearena: cannot reinterpret []%s as []%s: zero-sized types are not allowed", from, to))
+	}
+	bytes := uintptr(len(s.slice)) * from.Size()
+	if bytes%to.Size() != 0 {
+		panic(fmt.Sprintf("safearena: cannot reinterpret %d-byte slice as []%s: length is not a multiple of %d bytes",
+			bytes, to, to.Size()))
+	}
+	if uintptr(data)%uintptr(to.Align()) != 0 {
+		panic(fmt.Sprintf("safearena: cannot reinterpret []%s at %p as []%s: address is not %d-byte aligned",
+			from, data, to, to.Align()))
+	}
+
+	capacity := uintptr(cap(s.slice)) * from.Size() / to.Size()
+	view := unsafe.Slice((*To)(data), capacity)[:bytes/to.Size()]
+	return Slice[To]{slice: view, arena: s.arena, gen: s.gen}
+}
@@ -0,0 +1,134 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestAllocSliceAligned(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	for _, align := range []int{8, 16, 32, 64, 4096} {
+		// Allocate an odd-sized value first so the arena offset is disturbed
+		_ = Alloc(a, [3]byte{})
+
+		s := AllocSliceAligned[float32](a, 100, align).Get()
+		if addr := uintptr(unsafe.Pointer(&s[0])); addr%uintptr(align) != 0 {
+			t.Errorf("align %d: address %#x is not aligned", align, addr)
+		}
+		if len(s) != 100 || cap(s) != 100 {
+			t.Errorf("align %d: expected len and cap 100, got %d and %d", align, len(s), cap(s))
+		}
+		for i, v := range s {
+			if v != 0 {
+				t.Errorf("align %d: expected zeroed element at %d, got %v", align, i, v)
+				break
+			}
+		}
+	}
+}
+
+func TestAllocSliceAlignedOddElement(t *testing.T) {
+	type vec3 struct{ X, Y, Z float32 } // 12 bytes
+
+	a := New()
+	defer a.Free()
+
+	s := AllocSliceAligned[vec3](a, 10, 64).Get()
+	if addr := uintptr(unsafe.Pointer(&s[0])); addr%64 != 0 {
+		t.Errorf("address %#x is not 64-byte aligned", addr)
+	}
+}
+
+func TestAllocSliceAlignedInvalid(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	for _, align := range []int{0, -8, 3, 48} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic for alignment %d", align)
+				}
+			}()
+			AllocSliceAligned[byte](a, 16, align)
+		}()
+	}
+}
+
+func TestAllocSliceAlignedAfterFree(t *testing.T) {
+	a := New()
+	s := AllocSliceAligned[int](a, 4, 32)
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on use after free")
+		}
+	}()
+	_ = s.Get()
+}
+
+func TestReinterpretSlice(t *testing.T) {
+	a := New()
+
+	buf := AllocSliceAligned[byte](a, 16, 4)
+	words := ReinterpretSlice[uint32](buf)
+	if words.Len() != 4 {
+		t.Fatalf("expected 4 words, got %d", words.Len())
+	}
+	words.Fill(0x01010101)
+	for i, b := range buf.Get() {
+		if b != 1 {
+			t.Fatalf("expected writes through the view to reach byte %d, got %d", i, b)
+		}
+	}
+	back := ReinterpretSlice[byte](words)
+	if back.Len() != 16 || unsafe.SliceData(back.Get()) != unsafe.SliceData(buf.Get()) {
+		t.Error("expected the round trip to cover the same memory")
+	}
+
+	a.Free()
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	words.Get()
+}
+
+func TestReinterpretSliceInvalid(t *testing.T) {
+	a := New()
+	defer a.Free()
+	buf := AllocSliceAligned[byte](a, 16, 8)
+
+	for name, tc := range map[string]struct {
+		reinterpret func()
+		want        string
+	}{
+		"indivisible": {func() { ReinterpretSlice[uint32](buf.Slice(0, 6)) }, "6-byte slice as []uint32: length is not a multiple of 4 bytes"},
+		"misaligned":  {func() { ReinterpretSlice[uint32](buf.Slice(1, 9)) }, "is not 4-byte aligned"},
+		"pointers":    {func() { ReinterpretSlice[*int](buf.Slice(0, 8)) }, "pointer-bearing types are not allowed"},
+		"zero-sized":  {func() { ReinterpretSlice[struct{}](buf) }, "zero-sized types are not allowed"},
+		"freed": {func() {
+			b := New()
+			s := AllocSlice[byte](b, 8)
+			b.Free()
+			ReinterpretSlice[uint64](s)
+		}, "use after free"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.reinterpret()
+		})
+	}
+}
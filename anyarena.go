@@ -0,0 +1,109 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// AnyArena is the behavior shared by Arena and ArenaOpt.
+// Go does not allow generic methods, so typed allocation stays with the
+// package-level functions (Alloc, AllocOpt, ...); AnyArena exposes raw byte
+// allocation, which is enough for buffer-oriented code that should run on
+// either arena type.
+type AnyArena interface {
+	// AllocBytes allocates a zeroed byte slice of length n in the arena.
+	// The slice is valid only until the arena is freed.
+	// Panics if the arena has already been freed.
+	AllocBytes(n int) []byte
+
+	// ID returns the arena's identifier as used in error messages
+	ID() uint64
+
+	// IsFreed reports whether the arena has been freed
+	IsFreed() bool
+}
+
+// Hint selects which arena implementation ScopedAuto uses
+type Hint int
+
+const (
+	// HintDebug selects the safe Arena, which produces detailed diagnostics
+	// (stack traces, recovery hints, and allocation sites in Debug mode).
+	// Use it for small or infrequent work.
+	HintDebug Hint = iota
+
+	// HintHot selects the optimized ArenaOpt for hot paths where the
+	// per-operation overhead matters more than diagnostics.
+	HintHot
+)
+
+// String returns the hint name
+func (h Hint) String() string {
+	switch h {
+	case HintDebug:
+		return "HintDebug"
+	case HintHot:
+		return "HintHot"
+	default:
+		return fmt.Sprintf("Hint(%d)", int(h))
+	}
+}
+
+// newAnyArena creates the arena implementation selected by hint
+func newAnyArena(hint Hint) (AnyArena, func()) {
+	switch hint {
+	case HintHot:
+		a := NewOpt()
+		return a, a.Free
+	case HintDebug:
+		a := New()
+		return a, a.Free
+	default:
+		panic(fmt.Sprintf("safearena: unknown arena hint %v", hint))
+	}
+}
+
+// AllocBytes allocates a zeroed byte slice of length n in the arena.
+// The returned slice is not lifetime-checked; prefer AllocSlice when the
+// arena type is known.
+//
+// Panics if the arena has already been freed.
+func (a *Arena) AllocBytes(n int) []byte {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	buf := a.makeBytes(n, n)
+	if a.debug != nil && n > 0 {
+		a.recordSliceAlloc(unsafe.Pointer(unsafe.SliceData(buf)), captureStack(2), reflect.TypeFor[[]byte](), uintptr(n))
+	}
+	return buf
+}
+
+// ID returns the arena's identifier
+func (a *Arena) ID() uint64 {
+	return a.id
+}
+
+// AllocBytes allocates a zeroed byte slice of length n in the arena
+func (a *ArenaOpt) AllocBytes(n int) []byte {
+	if a.freed.Load() {
+		panic(fmt.Sprintf("arena %d: allocation after free", a.id))
+	}
+	if a.stats != nil {
+		a.stats.add(int64(n))
+	}
+	return backingMakeSlice[byte](a.inner, n, n)
+}
+
+// ID returns the arena's identifier
+func (a *ArenaOpt) ID() uint64 {
+	return a.id
+}
+
+// IsFreed reports whether the arena has been freed, without panicking
+func (a *ArenaOpt) IsFreed() bool {
+	return a.freed.Load()
+}
@@ -0,0 +1,39 @@
+//go:build !goexperiment.arenas
+
+package safearena
+
+import "reflect"
+
+// arenasEnabled reports whether memory comes from Go arenas
+// (GOEXPERIMENT=arenas) rather than the heap fallback
+const arenasEnabled = false
+
+// backing is the memory source behind Arena and ArenaOpt. Without
+// GOEXPERIMENT=arenas it is the garbage-collected heap: allocations are
+// ordinary new and make calls, and Free releases nothing. Lifetime checks
+// live in Arena itself, so they behave the same either way.
+type backing struct{}
+
+// newBacking creates an empty memory source
+func newBacking() *backing {
+	return &backing{}
+}
+
+// Free is a no-op: heap memory is reclaimed by the garbage collector once
+// nothing references it
+func (b *backing) Free() {}
+
+// backingNew allocates a zeroed T
+func backingNew[T any](*backing) *T {
+	return new(T)
+}
+
+// backingMakeSlice allocates a zeroed []T
+func backingMakeSlice[T any](_ *backing, length, capacity int) []T {
+	return make([]T, length, capacity)
+}
+
+// backingNewOf allocates a zeroed value of type t and returns a pointer to it
+func backingNewOf(_ *backing, t reflect.Type) reflect.Value {
+	return reflect.New(t)
+}
@@ -0,0 +1,35 @@
+//go:build goexperiment.arenas
+
+package safearena
+
+import (
+	"arena"
+	"reflect"
+)
+
+// arenasEnabled reports whether memory comes from Go arenas
+// (GOEXPERIMENT=arenas) rather than the heap fallback
+const arenasEnabled = true
+
+// backing is the memory source behind Arena and ArenaOpt
+type backing = arena.Arena
+
+// newBacking creates an empty memory source
+func newBacking() *backing {
+	return arena.NewArena()
+}
+
+// backingNew allocates a zeroed T
+func backingNew[T any](b *backing) *T {
+	return arena.New[T](b)
+}
+
+// backingMakeSlice allocates a zeroed []T
+func backingMakeSlice[T any](b *backing, length, capacity int) []T {
+	return arena.MakeSlice[T](b, length, capacity)
+}
+
+// backingNewOf allocates a zeroed value of type t and returns a pointer to it
+func backingNewOf(b *backing, t reflect.Type) reflect.Value {
+	return reflect.ArenaNew(b, t)
+}
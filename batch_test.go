@@ -0,0 +1,153 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAllocN(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	ptrs := AllocN[int](a, 10)
+	if len(ptrs) != 10 {
+		t.Fatalf("expected 10 pointers, got %d", len(ptrs))
+	}
+
+	for i, p := range ptrs {
+		if *p.Get() != 0 {
+			t.Errorf("index %d: expected zero value", i)
+		}
+		*p.Get() = i * i
+	}
+
+	for i, p := range ptrs {
+		if p.Deref() != i*i {
+			t.Errorf("index %d: expected %d, got %d", i, i*i, p.Deref())
+		}
+	}
+}
+
+func TestAllocNWithSliceAliasing(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	ptrs, view := AllocNWithSlice[int](a, 5)
+
+	// Write through a Ptr, observe through the Slice
+	*ptrs[3].Get() = 42
+	if view.Get()[3] != 42 {
+		t.Errorf("expected slice to observe Ptr write, got %d", view.Get()[3])
+	}
+
+	// Write through the Slice, observe through a Ptr
+	view.Get()[1] = 7
+	if ptrs[1].Deref() != 7 {
+		t.Errorf("expected Ptr to observe slice write, got %d", ptrs[1].Deref())
+	}
+
+	if len(view.Get()) != 5 {
+		t.Errorf("expected slice length 5, got %d", len(view.Get()))
+	}
+}
+
+func TestAllocNWithSliceAfterFree(t *testing.T) {
+	t.Run("ptr panics", func(t *testing.T) {
+		a := New()
+		ptrs, _ := AllocNWithSlice[int](a, 3)
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on use-after-free")
+			}
+		}()
+		_ = ptrs[0].Get()
+	})
+
+	t.Run("slice panics", func(t *testing.T) {
+		a := New()
+		_, view := AllocNWithSlice[int](a, 3)
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on use-after-free")
+			}
+		}()
+		_ = view.Get()
+	})
+
+	t.Run("alloc panics", func(t *testing.T) {
+		a := New()
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on allocation after free")
+			}
+		}()
+		_ = AllocN[int](a, 3)
+	})
+}
+
+func TestAllocMany(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	vals := []int{10, 20, 30}
+	ptrs := AllocMany(a, vals)
+	vals[0] = 99 // The arena holds copies
+
+	if len(ptrs) != 3 {
+		t.Fatalf("expected 3 pointers, got %d", len(ptrs))
+	}
+	for i, want := range []int{10, 20, 30} {
+		if got := ptrs[i].Deref(); got != want {
+			t.Errorf("index %d: expected %d, got %d", i, want, got)
+		}
+	}
+	if got := a.Stats().Allocations; got != 1 {
+		t.Errorf("expected a single allocation, got %d", got)
+	}
+}
+
+func TestAllocContig(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	vals := []string{"a", "b", "c"}
+	s := AllocContig(a, vals)
+	vals[0] = "changed"
+
+	got := s.Get()
+	if len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("expected [a b c], got %v", got)
+	}
+}
+
+func TestAllocManyAfterFree(t *testing.T) {
+	tests := map[string]func(a *Arena){
+		"AllocMany":   func(a *Arena) { AllocMany(a, []int{1}) },
+		"AllocContig": func(a *Arena) { AllocContig(a, []int{1}) },
+	}
+
+	for name, alloc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := New()
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "allocation after free") {
+					t.Errorf("expected allocation after free panic, got %q", msg)
+				}
+				if !strings.Contains(msg, "batch_test.go") {
+					t.Errorf("expected caller location, got %q", msg)
+				}
+			}()
+			alloc(a)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package safearena
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// AllocFunc allocates a zeroed byte slice of length n. Benchmark passes a
+// workload one backed by an arena on one run and by make on the other, so
+// the same code measures both.
+type AllocFunc func(n int) []byte
+
+// RunStats describes one side of a Benchmark comparison, per iteration of the
+// workload unless noted
+type RunStats struct {
+	NsPerOp    int64         // Wall time
+	BytesPerOp int64         // Garbage-collected heap bytes allocated
+	ArenaBytes int64         // Bytes allocated through AllocFunc from the arena; 0 for the heap run
+	GCCycles   uint32        // Garbage collections during the whole run
+	GCPause    time.Duration // Total stop-the-world pause during the whole run
+}
+
+// Comparison is the result of Benchmark
+type Comparison struct {
+	Iterations int // Workload runs per side
+	Arena      RunStats
+	Heap       RunStats
+}
+
+// Speedup returns how many times faster the arena run was than the heap run;
+// below 1 means the arena was slower
+func (c Comparison) Speedup() float64 {
+	if c.Arena.NsPerOp == 0 {
+		return 0
+	}
+	return float64(c.Heap.NsPerOp) / float64(c.Arena.NsPerOp)
+}
+
+// String formats the comparison as a short report
+func (c Comparison) String() string {
+	return fmt.Sprintf("%d iterations\n"+
+		"  arena: %d ns/op, %d heap B/op, %d arena B/op, %d GCs, %v GC pause\n"+
+		"  heap:  %d ns/op, %d heap B/op, %d GCs, %v GC pause\n"+
+		"  speedup: %.2fx",
+		c.Iterations,
+		c.Arena.NsPerOp, c.Arena.BytesPerOp, c.Arena.ArenaBytes, c.Arena.GCCycles, c.Arena.GCPause,
+		c.Heap.NsPerOp, c.Heap.BytesPerOp, c.Heap.GCCycles, c.Heap.GCPause,
+		c.Speedup())
+}
+
+// benchTarget is how long Benchmark aims to run each side
+var benchTarget = time.Second
+
+// Benchmark answers "is an arena faster for my workload?". It runs workload
+// repeatedly with an AllocFunc backed by a fresh arena per iteration (freed
+// after each one, as a request-scoped arena would be), then the same number
+// of times with an AllocFunc backed by make, and reports time, heap bytes,
+// and GC activity for both.
+//
+// The iteration count grows until the arena side runs for about a second,
+// as with testing.B. Workloads should do all their temporary allocation
+// through alloc so the two runs are comparable. Benchmark triggers garbage
+// collections between runs and is meant for experiments and tests, not for
+// use while serving traffic.
+//
+// Example:
+//
+//	c := safearena.Benchmark(func(alloc safearena.AllocFunc) {
+//	    buf := alloc(4096)
+//	    n := copy(buf, payload)
+//	    parse(buf[:n])
+//	})
+//	fmt.Println(c)
+func Benchmark(workload func(alloc AllocFunc)) Comparison {
+	var arenaBytes int64
+	runArena := func(n int) {
+		for i := 0; i < n; i++ {
+			a := New()
+			workload(a.AllocBytes)
+			arenaBytes += a.Stats().Bytes
+			a.Free()
+		}
+	}
+	runHeap := func(n int) {
+		alloc := func(n int) []byte { return make([]byte, n) }
+		for i := 0; i < n; i++ {
+			workload(alloc)
+		}
+	}
+
+	n := 1
+	for {
+		start := time.Now()
+		runArena(n)
+		elapsed := time.Since(start)
+		if elapsed >= benchTarget || n >= 1e9 {
+			break
+		}
+		n = nextIterations(n, elapsed)
+	}
+
+	c := Comparison{Iterations: n}
+	arenaBytes = 0
+	c.Arena = measure(n, runArena)
+	c.Arena.ArenaBytes = arenaBytes / int64(n)
+	c.Heap = measure(n, runHeap)
+	return c
+}
+
+// nextIterations predicts the iteration count that runs for benchTarget,
+// given that n iterations took elapsed, growing by at most 100x per step
+func nextIterations(n int, elapsed time.Duration) int {
+	next := n * 100
+	if elapsed > 0 {
+		next = min(next, int(int64(n)*int64(benchTarget)/int64(elapsed)*6/5))
+	}
+	return max(next, n+1)
+}
+
+// measure runs run(n) and returns its per-iteration cost
+func measure(n int, run func(n int)) RunStats {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	run(n)
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return RunStats{
+		NsPerOp:    elapsed.Nanoseconds() / int64(n),
+		BytesPerOp: int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+		GCCycles:   after.NumGC - before.NumGC,
+		GCPause:    time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+	}
+}
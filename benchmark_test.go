@@ -0,0 +1,36 @@
+package safearena
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBenchmark(t *testing.T) {
+	old := benchTarget
+	benchTarget = 20 * time.Millisecond
+	t.Cleanup(func() { benchTarget = old })
+
+	c := Benchmark(func(alloc AllocFunc) {
+		for i := 0; i < 16; i++ {
+			buf := alloc(256)
+			buf[0] = byte(i)
+		}
+	})
+
+	if c.Iterations < 1 {
+		t.Fatalf("expected at least 1 iteration, got %d", c.Iterations)
+	}
+	if c.Arena.ArenaBytes != 16*256 {
+		t.Errorf("expected %d arena bytes per op, got %d", 16*256, c.Arena.ArenaBytes)
+	}
+	if c.Heap.ArenaBytes != 0 || c.Heap.BytesPerOp < 16*256 {
+		t.Errorf("expected the heap run to allocate on the heap, got %+v", c.Heap)
+	}
+	if c.Arena.NsPerOp <= 0 || c.Heap.NsPerOp <= 0 || c.Speedup() <= 0 {
+		t.Errorf("expected positive timings, got %+v", c)
+	}
+	if s := c.String(); !strings.Contains(s, "arena:") || !strings.Contains(s, "speedup:") {
+		t.Errorf("unexpected report %q", s)
+	}
+}
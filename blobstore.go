@@ -0,0 +1,101 @@
+package safearena
+
+import (
+	"crypto/sha256"
+)
+
+// BlobStore is a content-addressed, deduplicating byte store bound to an arena.
+// Blob contents are copied into arena memory, so deduplicated storage stays out
+// of the GC for the arena's lifetime; only the hash index lives on the heap.
+//
+// Resetting the arena empties the store, and releasing a Mark drops the blobs
+// stored after it.
+//
+// A BlobStore is not safe for concurrent use.
+type BlobStore struct {
+	arena *Arena
+	gen   uint64 // Arena generation when stale blobs were last dropped
+	blobs map[[32]byte]Ptr[[]byte]
+}
+
+// NewBlobStore creates a BlobStore whose blobs live in the given arena.
+//
+// Example:
+//
+//	store := safearena.NewBlobStore(a)
+//	_, h1 := store.Put(chunk)
+//	_, h2 := store.Put(chunk) // Deduplicated: h1 == h2, stored once
+func NewBlobStore(a *Arena) *BlobStore {
+	return &BlobStore{
+		arena: a,
+		gen:   a.gen.Load(),
+		blobs: make(map[[32]byte]Ptr[[]byte]),
+	}
+}
+
+// Put stores data under its SHA-256 hash and returns the stored blob and hash.
+// If an identical blob was already stored, the existing blob is returned and
+// nothing is copied.
+//
+// Panics if the arena has already been freed.
+func (b *BlobStore) Put(data []byte) (Ptr[[]byte], [32]byte) {
+	if b.arena.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(b.arena, "allocation after free", stack, AllocAfterFree))
+	}
+	b.dropStale()
+
+	hash := sha256.Sum256(data)
+	if blob, ok := b.blobs[hash]; ok {
+		return blob, hash
+	}
+
+	buf := b.arena.makeBytes(len(data), len(data))
+	copy(buf, data)
+
+	blob := Alloc(b.arena, buf)
+	b.blobs[hash] = blob
+	return blob, hash
+}
+
+// Get returns the blob stored under hash, and whether it was found.
+//
+// Panics if the arena has been freed.
+func (b *BlobStore) Get(hash [32]byte) (Slice[byte], bool) {
+	if b.arena.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(b.arena, "use after free", stack, UseAfterFree))
+	}
+	b.dropStale()
+
+	blob, ok := b.blobs[hash]
+	if !ok {
+		return Slice[byte]{}, false
+	}
+	return Slice[byte]{
+		slice: *blob.ptr,
+		arena: b.arena,
+		gen:   blob.gen,
+	}, true
+}
+
+// Len returns the number of distinct blobs stored.
+func (b *BlobStore) Len() int {
+	b.dropStale()
+	return len(b.blobs)
+}
+
+// dropStale forgets blobs invalidated since the arena generation last changed
+// (by Reset, or Release of a mark taken before they were stored)
+func (b *BlobStore) dropStale() {
+	gen := b.arena.gen.Load()
+	if gen == b.gen {
+		return
+	}
+	for hash, blob := range b.blobs {
+		if !b.arena.live(blob.gen) {
+			delete(b.blobs, hash)
+		}
+	}
+	b.gen = gen
+}
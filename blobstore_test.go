@@ -0,0 +1,118 @@
+package safearena
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestBlobStoreDedup(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	store := NewBlobStore(a)
+
+	p1, h1 := store.Put([]byte("same content"))
+	p2, h2 := store.Put([]byte("same content"))
+
+	if h1 != h2 {
+		t.Error("expected identical hashes for identical blobs")
+	}
+	if p1.Get() != p2.Get() {
+		t.Error("expected duplicate blob to return the same pointer")
+	}
+	if store.Len() != 1 {
+		t.Errorf("expected 1 stored blob, got %d", store.Len())
+	}
+	if h1 != sha256.Sum256([]byte("same content")) {
+		t.Error("expected SHA-256 hash")
+	}
+}
+
+func TestBlobStoreDistinct(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	store := NewBlobStore(a)
+
+	_, h1 := store.Put([]byte("first"))
+	_, h2 := store.Put([]byte("second"))
+
+	if h1 == h2 {
+		t.Error("expected different hashes for different blobs")
+	}
+	if store.Len() != 2 {
+		t.Errorf("expected 2 stored blobs, got %d", store.Len())
+	}
+
+	s1, ok := store.Get(h1)
+	if !ok || string(s1.Get()) != "first" {
+		t.Errorf("expected first, got %q (found=%v)", s1.Get(), ok)
+	}
+	s2, ok := store.Get(h2)
+	if !ok || string(s2.Get()) != "second" {
+		t.Errorf("expected second, got %q (found=%v)", s2.Get(), ok)
+	}
+
+	if _, ok := store.Get(sha256.Sum256([]byte("missing"))); ok {
+		t.Error("expected missing blob not to be found")
+	}
+}
+
+func TestBlobStoreCopiesInput(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	store := NewBlobStore(a)
+
+	data := []byte("mutable")
+	_, h := store.Put(data)
+	data[0] = 'X'
+
+	s, _ := store.Get(h)
+	if string(s.Get()) != "mutable" {
+		t.Errorf("expected stored blob to be independent of input, got %q", s.Get())
+	}
+}
+
+func TestBlobStoreAfterFree(t *testing.T) {
+	t.Run("put", func(t *testing.T) {
+		a := New()
+		store := NewBlobStore(a)
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on put after free")
+			}
+		}()
+		store.Put([]byte("data"))
+	})
+
+	t.Run("get", func(t *testing.T) {
+		a := New()
+		store := NewBlobStore(a)
+		_, h := store.Put([]byte("data"))
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on get after free")
+			}
+		}()
+		store.Get(h)
+	})
+
+	t.Run("stored blob", func(t *testing.T) {
+		a := New()
+		store := NewBlobStore(a)
+		p, _ := store.Put([]byte("data"))
+		a.Free()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic on blob access after free")
+			}
+		}()
+		_ = p.Get()
+	})
+}
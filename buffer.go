@@ -0,0 +1,105 @@
+package safearena
+
+// ArenaBuffer is the arena analogue of bytes.Buffer: a variable-sized byte
+// buffer whose storage lives in the arena. When the buffer is full it doubles
+// its capacity by reallocating from the arena and copying, so request-scoped
+// serialization produces no garbage for the GC to collect.
+//
+// Every method panics if the arena has been freed or reset.
+// An ArenaBuffer is not safe for concurrent use.
+type ArenaBuffer struct {
+	arena *Arena
+	gen   uint64 // Arena generation the buffer belongs to
+	buf   []byte
+}
+
+// NewBuffer returns an empty ArenaBuffer with an initial capacity of capacity bytes.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	buf := a.NewBuffer(1024)
+//	buf.WriteString(`{"id":`)
+//	buf.Write(strconv.AppendInt(nil, id, 10))
+//	buf.WriteByte('}')
+//	send(buf.Bytes().Get())
+func (a *Arena) NewBuffer(capacity int) *ArenaBuffer {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	return &ArenaBuffer{
+		arena: a,
+		gen:   a.gen.Load(),
+		buf:   a.makeBytes(0, capacity),
+	}
+}
+
+// Write appends p to the buffer. It always returns len(p) and a nil error.
+func (b *ArenaBuffer) Write(p []byte) (int, error) {
+	b.check()
+	b.buf = growBytes(b.arena, b.buf, len(p))
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer. It always returns len(s) and a nil error.
+func (b *ArenaBuffer) WriteString(s string) (int, error) {
+	b.check()
+	b.buf = growBytes(b.arena, b.buf, len(s))
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte appends c to the buffer. It always returns nil.
+func (b *ArenaBuffer) WriteByte(c byte) error {
+	b.check()
+	b.buf = growBytes(b.arena, b.buf, 1)
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// Len returns the number of bytes in the buffer
+func (b *ArenaBuffer) Len() int {
+	b.check()
+	return len(b.buf)
+}
+
+// Cap returns the capacity of the buffer's arena storage
+func (b *ArenaBuffer) Cap() int {
+	b.check()
+	return cap(b.buf)
+}
+
+// Reset empties the buffer but keeps its storage for reuse
+func (b *ArenaBuffer) Reset() {
+	b.check()
+	b.buf = b.buf[:0]
+}
+
+// Bytes returns the buffer content as an arena slice.
+// The slice aliases the buffer and is only valid until the next modification.
+func (b *ArenaBuffer) Bytes() Slice[byte] {
+	b.check()
+	return Slice[byte]{
+		slice: b.buf,
+		arena: b.arena,
+		gen:   b.gen,
+	}
+}
+
+// String returns the buffer content as a heap string
+func (b *ArenaBuffer) String() string {
+	b.check()
+	return string(b.buf)
+}
+
+// check panics if the buffer's arena has been freed or reset
+func (b *ArenaBuffer) check() {
+	if b.arena.freed.Load() || !b.arena.live(b.gen) {
+		stack := captureStack(3)
+		panic(b.arena.staleError(b.gen, stack, nil))
+	}
+}
@@ -0,0 +1,152 @@
+package safearena
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestArenaBuffer(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := a.NewBuffer(4)
+	buf.WriteString("he")
+	buf.Write([]byte("ll"))
+	buf.WriteByte('o')
+
+	if buf.String() != "hello" {
+		t.Errorf("expected hello, got %q", buf.String())
+	}
+	if got := string(buf.Bytes().Get()); got != "hello" {
+		t.Errorf("expected hello from Bytes, got %q", got)
+	}
+	if buf.Len() != 5 {
+		t.Errorf("expected length 5, got %d", buf.Len())
+	}
+}
+
+func TestArenaBufferGrowth(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := a.NewBuffer(8)
+	buf.WriteString("12345678")
+	if buf.Cap() != 8 {
+		t.Fatalf("expected capacity 8, got %d", buf.Cap())
+	}
+
+	buf.WriteByte('9')
+	if buf.Cap() != 16 {
+		t.Errorf("expected capacity to double to 16, got %d", buf.Cap())
+	}
+
+	long := strings.Repeat("x", 100)
+	buf.WriteString(long)
+	if buf.String() != "123456789"+long {
+		t.Error("expected content to survive growth")
+	}
+}
+
+func TestArenaBufferReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := a.NewBuffer(0)
+	buf.WriteString(strings.Repeat("x", 64))
+	capBefore := buf.Cap()
+	buf.Reset()
+
+	if buf.Len() != 0 {
+		t.Errorf("expected empty buffer after reset, got %d bytes", buf.Len())
+	}
+	if buf.Cap() != capBefore {
+		t.Errorf("expected capacity %d to be kept, got %d", capBefore, buf.Cap())
+	}
+
+	buf.WriteString("new")
+	if buf.String() != "new" {
+		t.Errorf("expected new, got %q", buf.String())
+	}
+}
+
+func TestArenaBufferAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(b *ArenaBuffer)
+	}{
+		{"write", func(b *ArenaBuffer) { b.Write([]byte("x")) }},
+		{"write string", func(b *ArenaBuffer) { b.WriteString("x") }},
+		{"write byte", func(b *ArenaBuffer) { b.WriteByte('x') }},
+		{"len", func(b *ArenaBuffer) { b.Len() }},
+		{"cap", func(b *ArenaBuffer) { b.Cap() }},
+		{"reset", func(b *ArenaBuffer) { b.Reset() }},
+		{"bytes", func(b *ArenaBuffer) { b.Bytes() }},
+		{"string", func(b *ArenaBuffer) { _ = b.String() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			buf := a.NewBuffer(8)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use(buf)
+		})
+	}
+}
+
+// serializeRecords writes a JSON-like document of n records into w
+func serializeRecords(w interface {
+	io.Writer
+	io.ByteWriter
+}, n int) {
+	w.WriteByte('[')
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(w, `{"id":%d,"name":"record-%d"}`, i, i)
+		w.WriteByte(',')
+	}
+	w.WriteByte(']')
+}
+
+// Compare GC pressure of a serialize-heavy workload
+func BenchmarkSerializeBuffer(b *testing.B) {
+	b.Run("ArenaBuffer", func(b *testing.B) {
+		b.ReportAllocs()
+		runtime.GC()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		for i := 0; i < b.N; i++ {
+			ScopedPtr(func(a *Arena) {
+				serializeRecords(a.NewBuffer(64), 100)
+			})
+		}
+
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.NumGC-before.NumGC), "gc-count")
+	})
+
+	b.Run("bytes.Buffer", func(b *testing.B) {
+		b.ReportAllocs()
+		runtime.GC()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		for i := 0; i < b.N; i++ {
+			serializeRecords(bytes.NewBuffer(make([]byte, 0, 64)), 100)
+		}
+
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.NumGC-before.NumGC), "gc-count")
+	})
+}
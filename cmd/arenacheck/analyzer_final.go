@@ -4,6 +4,7 @@ package main
 
 import (
 	"fmt"
+	"go/token"
 	"go/types"
 	"strings"
 
@@ -21,19 +22,21 @@ var AnalyzerFinal2 = &analysis.Analyzer{
 
 func runFinal2(pass *analysis.Pass) (interface{}, error) {
 	ssaProg := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	scopedFns := findScopedFuncs(ssaProg.SrcFuncs)
 
 	for _, fn := range ssaProg.SrcFuncs {
 		if fn == nil || fn.Blocks == nil {
 			continue
 		}
-		checkFunctionFinal2(pass, fn)
+		checkFunctionFinal2(pass, fn, scopedFns[fn])
 	}
 
 	return nil, nil
 }
 
 type arenaInfo struct {
-	value ssa.Value
+	value   ssa.Value
+	readers bool // Created with NewConcurrent or NewWithTTL: Free waits for AcquireRead
 }
 
 type allocInfo struct {
@@ -42,13 +45,24 @@ type allocInfo struct {
 	allocPos string
 }
 
-func checkFunctionFinal2(pass *analysis.Pass, fn *ssa.Function) {
+func checkFunctionFinal2(pass *analysis.Pass, fn *ssa.Function, scoped bool) {
 	arenas := make(map[ssa.Value]*arenaInfo)
+
+	// A safearena.Scoped callback's arena is freed when the callback returns
+	if scoped {
+		for _, param := range fn.Params {
+			if isSafeArenaPtr(param.Type()) {
+				arenas[param] = &arenaInfo{value: param}
+			}
+		}
+	}
 	allocations := make(map[ssa.Value]*allocInfo)
 	// Track what arena values are stored into what addresses
 	storesTo := make(map[ssa.Value]ssa.Value) // addr -> value
 	// Track Free() calls: instruction -> arena
 	freeInstrs := make(map[ssa.Instruction]ssa.Value)
+	// Unchecked views of arena memory returned by SliceOpt.UnsafeGet
+	unsafeViews := make(map[ssa.Value]*allocInfo)
 
 	// First pass: find arenas, allocations, and Free() calls
 	for _, block := range fn.Blocks {
@@ -78,6 +92,28 @@ func checkFunctionFinal2(pass *analysis.Pass, fn *ssa.Function) {
 					}
 				}
 
+				// s.UnsafeGet() - raw slice with no lifetime tracking
+				if isUnsafeGet(callee) && len(call.Call.Args) > 0 && isSafeArenaType(call.Call.Args[0].Type()) {
+					unsafeViews[call] = &allocInfo{
+						value:    call,
+						allocPos: pass.Fset.Position(call.Pos()).String(),
+					}
+				}
+
+				// safearena.New() and friends, safearena.Alloc(a, ...) and friends
+				switch name := safeArenaFunc(callee); {
+				case safeArenaConstructors[name], readerArenaConstructors[name]:
+					arenas[call] = &arenaInfo{value: call, readers: readerArenaConstructors[name]}
+				case safeArenaAllocators[name] && len(call.Call.Args) > 0:
+					if arenaInfo, ok := arenas[call.Call.Args[0]]; ok {
+						allocations[call] = &allocInfo{
+							arena:    arenaInfo,
+							value:    call,
+							allocPos: pass.Fset.Position(call.Pos()).String(),
+						}
+					}
+				}
+
 				// arena.Free() - track explicit Free calls
 				if strings.Contains(fullName, ".Free") || (callee.Name() == "Free" && len(call.Call.Args) > 0) {
 					// Try to find which arena is being freed
@@ -98,32 +134,56 @@ func checkFunctionFinal2(pass *analysis.Pass, fn *ssa.Function) {
 		}
 	}
 
-	// Second pass: check returns, stores, and use-after-free
-	for _, block := range fn.Blocks {
-		freedArenas := make(map[ssa.Value]bool) // Track which arenas are freed in this block
+	// Second pass: check returns, stores, and use-after-free.
+	// Blocks are visited in dominator-tree preorder so each block starts with
+	// the arenas freed in its dominators: those Frees run on every path to the
+	// block. Frees on only some paths (e.g. inside an if) are tracked
+	// separately by mayFreeAtEntry, so that a use after `if cond { a.Free() }`
+	// is reported unless the use itself is confined to the !cond branch.
+	mayFreeIn := mayFreeAtEntry(fn, freeInstrs, arenas)
+	freedAtEnd := make(map[*ssa.BasicBlock]map[ssa.Value]bool)
+	for _, block := range dominatorOrder(fn) {
+		freedArenas := make(map[ssa.Value]bool) // Arenas freed on every path to this point
+		if idom := block.Idom(); idom != nil {
+			for arena := range freedAtEnd[idom] {
+				freedArenas[arena] = true
+			}
+		}
+		freedAtEnd[block] = freedArenas
+		mayFree := copyFreeSet(mayFreeIn[block]) // Frees that ran on some path to this point
 
 		for _, instr := range block.Instrs {
 			// Track when arenas are freed
 			if arena, ok := freeInstrs[instr]; ok {
 				freedArenas[arena] = true
+				mayFree[instr] = true
 			}
+			killFrees(mayFree, instr, freeInstrs, arenas)
 
 			// Check for uses of allocations after their arena was freed
-			if len(freedArenas) > 0 {
-				checkUseAfterFree(pass, instr, allocations, freedArenas, storesTo)
+			if len(freedArenas) > 0 && checkUseAfterFree(pass, instr, allocations, freedArenas, storesTo) {
+				continue
+			}
+			if len(mayFree) > 0 {
+				checkUseAfterMayFree(pass, instr, allocations, mayFree, freeInstrs, storesTo)
 			}
 
 			// Check returns
 			if ret, ok := instr.(*ssa.Return); ok {
 				for _, result := range ret.Results {
 					if alloc := findAllocation(result, allocations, storesTo); alloc != nil {
-						// Type check: only flag pointers
-						if isPointerType(result.Type()) {
+						// Type check: only flag pointers and SafeArena wrappers
+						if isPointerType(result.Type()) || isSafeArenaType(result.Type()) {
 							pass.Reportf(ret.Pos(),
 								"arena-allocated value escapes via return (allocated at %s)",
 								alloc.allocPos)
 						}
 					}
+					if view := findAllocation(result, unsafeViews, storesTo); view != nil {
+						pass.Reportf(ret.Pos(),
+							"UnsafeGet result escapes via return and dangles after Free() (obtained at %s)",
+							view.allocPos)
+					}
 				}
 			}
 
@@ -135,10 +195,55 @@ func checkFunctionFinal2(pass *analysis.Pass, fn *ssa.Function) {
 							"arena-allocated value escapes to global variable (allocated at %s)",
 							alloc.allocPos)
 					}
+					if view := findAllocation(store.Val, unsafeViews, storesTo); view != nil {
+						pass.Reportf(store.Pos(),
+							"UnsafeGet result escapes to global variable and dangles after Free() (obtained at %s)",
+							view.allocPos)
+					}
 				}
 			}
+
+			// Check arena values stored into collections that escape
+			switch inst := instr.(type) {
+			case *ssa.MapUpdate:
+				if isArenaValue(inst.Value, allocations, storesTo) {
+					if how := containerEscape(inst.Map); how != "" {
+						pass.Reportf(inst.Pos(),
+							"arena value stored in map escapes %s", how)
+					}
+				}
+			case *ssa.Store:
+				if idx, ok := inst.Addr.(*ssa.IndexAddr); ok && isArenaValue(inst.Val, allocations, storesTo) {
+					if how := containerEscape(idx.X); how != "" {
+						pass.Reportf(inst.Pos(),
+							"arena value stored in slice escapes %s", how)
+					}
+				}
+			case *ssa.Go:
+				// The goroutine may still be running after the arena is freed
+				if goroutineCapturesArena(inst, arenas, allocations, storesTo) {
+					pass.Reportf(inst.Pos(),
+						"arena value passed to goroutine may be used after Free()")
+				}
+			}
+		}
+	}
+}
+
+// dominatorOrder returns fn's blocks in dominator-tree preorder, followed by
+// any blocks outside the tree (such as the recover block)
+func dominatorOrder(fn *ssa.Function) []*ssa.BasicBlock {
+	order := fn.DomPreorder()
+	seen := make(map[*ssa.BasicBlock]bool, len(order))
+	for _, block := range order {
+		seen[block] = true
+	}
+	for _, block := range fn.Blocks {
+		if !seen[block] {
+			order = append(order, block)
 		}
 	}
+	return order
 }
 
 // findAllocation traces a value back to see if it comes from an arena allocation
@@ -180,6 +285,9 @@ func findAllocationRec(val ssa.Value, allocations map[ssa.Value]*allocInfo, stor
 	case *ssa.IndexAddr:
 		return findAllocationRec(v.X, allocations, storesTo, visited)
 
+	case *ssa.Slice:
+		return findAllocationRec(v.X, allocations, storesTo, visited)
+
 	case *ssa.Phi:
 		for _, edge := range v.Edges {
 			if alloc := findAllocationRec(edge, allocations, storesTo, visited); alloc != nil {
@@ -194,6 +302,313 @@ func findAllocationRec(val ssa.Value, allocations map[ssa.Value]*allocInfo, stor
 	return nil
 }
 
+// isUnsafeGet reports whether fn is UnsafeGet, or an instantiation of it
+func isUnsafeGet(fn *ssa.Function) bool {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	return fn.Name() == "UnsafeGet"
+}
+
+// safeArenaConstructors are the safearena functions that create an arena
+var safeArenaConstructors = map[string]bool{
+	"New":                 true,
+	"NewNamed":            true,
+	"NewWithLimit":        true,
+	"NewWithFinalizer":    true,
+	"NewOpt":              true,
+	"NewOptWithFinalizer": true,
+}
+
+// readerArenaConstructors are the safearena functions that create an arena
+// whose Free waits for goroutines holding AcquireRead
+var readerArenaConstructors = map[string]bool{
+	"NewConcurrent": true,
+	"NewWithTTL":    true,
+}
+
+// safeArenaAllocators are the safearena functions that allocate from the
+// arena passed as their first argument
+var safeArenaAllocators = map[string]bool{
+	"Alloc":             true,
+	"AllocSlice":        true,
+	"AllocSliceCap":     true,
+	"AllocSliceAligned": true,
+	"AllocContig":       true,
+	"AllocReflect":      true,
+	"AllocOpt":          true,
+	"AllocSliceOpt":     true,
+	"UnsafeAlloc":       true,
+}
+
+// safeArenaScopes are the safearena functions that call a function argument
+// with an arena and free the arena when it returns
+var safeArenaScopes = map[string]bool{
+	"Scoped":        true,
+	"ScopedErr":     true,
+	"ScopedContext": true,
+	"ScopedOpt":     true,
+}
+
+// safeArenaFunc returns the name of fn if it is a package-level function of
+// the safearena package (or an instantiation of one), or ""
+func safeArenaFunc(fn *ssa.Function) string {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	obj := fn.Object()
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Name() != "safearena" || fn.Signature.Recv() != nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// findScopedFuncs returns the functions passed as callbacks to the
+// safearena.Scoped family
+func findScopedFuncs(fns []*ssa.Function) map[*ssa.Function]bool {
+	scoped := make(map[*ssa.Function]bool)
+	for _, fn := range fns {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+				callee := call.Call.StaticCallee()
+				if callee == nil || !safeArenaScopes[safeArenaFunc(callee)] {
+					continue
+				}
+				for _, arg := range call.Call.Args {
+					switch f := arg.(type) {
+					case *ssa.Function:
+						scoped[f] = true
+					case *ssa.MakeClosure:
+						scoped[f.Fn.(*ssa.Function)] = true
+					}
+				}
+			}
+		}
+	}
+	return scoped
+}
+
+// isSafeArenaPtr reports whether t is *safearena.Arena or *safearena.ArenaOpt
+func isSafeArenaPtr(t types.Type) bool {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Name() == "safearena" && (obj.Name() == "Arena" || obj.Name() == "ArenaOpt")
+}
+
+// safeArenaTypes are the SafeArena wrapper types that reference arena memory
+var safeArenaTypes = map[string]bool{
+	"Ptr":      true,
+	"Slice":    true,
+	"PtrOpt":   true,
+	"SliceOpt": true,
+}
+
+// isSafeArenaType reports whether t is one of the safearena wrapper types
+func isSafeArenaType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Name() == "safearena" && safeArenaTypes[obj.Name()]
+}
+
+// isArenaValue reports whether val references arena memory, either as a
+// SafeArena wrapper or as a raw arena allocation
+func isArenaValue(val ssa.Value, allocations map[ssa.Value]*allocInfo, storesTo map[ssa.Value]ssa.Value) bool {
+	return isSafeArenaType(val.Type()) || findAllocation(val, allocations, storesTo) != nil
+}
+
+// isArenaRef reports whether t is, or points to, an arena or a value that
+// references arena memory
+func isArenaRef(t types.Type) bool {
+	for {
+		ptr, ok := types.Unalias(t).(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = ptr.Elem()
+	}
+	if isSafeArenaType(t) {
+		return true
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return false
+	}
+	switch obj.Pkg().Path() {
+	case "arena":
+		return obj.Name() == "Arena"
+	default:
+		return obj.Pkg().Name() == "safearena" && (obj.Name() == "Arena" || obj.Name() == "ArenaOpt")
+	}
+}
+
+// goroutineCapturesArena reports whether a go statement hands an arena or
+// arena memory to the new goroutine, through call arguments or variables
+// captured by a closure. Arenas from NewConcurrent or NewWithTTL, and their
+// memory, are exempt when the goroutine takes read access with AcquireRead,
+// since Free then waits for it.
+func goroutineCapturesArena(g *ssa.Go, arenas map[ssa.Value]*arenaInfo, allocations map[ssa.Value]*allocInfo, storesTo map[ssa.Value]ssa.Value) bool {
+	vals := append([]ssa.Value(nil), g.Call.Args...)
+	if closure, ok := g.Call.Value.(*ssa.MakeClosure); ok {
+		vals = append(vals, closure.Bindings...)
+	}
+	acquires := callsAcquireRead(g.Call.StaticCallee())
+
+	for _, val := range vals {
+		if acquires && fromReaderArena(val, arenas, allocations, storesTo) {
+			continue
+		}
+		if isArenaRef(val.Type()) || findAllocation(val, allocations, storesTo) != nil {
+			return true
+		}
+		// Variable captured by reference: check what was stored in its cell
+		if stored, ok := storesTo[val]; ok && findAllocation(stored, allocations, storesTo) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fromReaderArena reports whether val is, or is a variable holding, an arena
+// created with NewConcurrent or NewWithTTL, or memory allocated from one
+func fromReaderArena(val ssa.Value, arenas map[ssa.Value]*arenaInfo, allocations map[ssa.Value]*allocInfo, storesTo map[ssa.Value]ssa.Value) bool {
+	if stored, ok := storesTo[val]; ok {
+		val = stored
+	}
+	if isReaderArena(val, arenas, storesTo) {
+		return true
+	}
+	if alloc := findAllocation(val, allocations, storesTo); alloc != nil {
+		return alloc.arena.readers
+	}
+	// Allocations from an arena variable that a closure captures are not
+	// tracked, because the arena argument is loaded from the variable
+	if call, ok := val.(*ssa.Call); ok && len(call.Call.Args) > 0 {
+		if callee := call.Call.StaticCallee(); callee != nil && safeArenaAllocators[safeArenaFunc(callee)] {
+			return isReaderArena(call.Call.Args[0], arenas, storesTo)
+		}
+	}
+	return false
+}
+
+// isReaderArena reports whether val, possibly loaded from a variable, is an
+// arena created with NewConcurrent or NewWithTTL
+func isReaderArena(val ssa.Value, arenas map[ssa.Value]*arenaInfo, storesTo map[ssa.Value]ssa.Value) bool {
+	if load, ok := val.(*ssa.UnOp); ok && load.Op == token.MUL {
+		if stored, ok := storesTo[load.X]; ok {
+			val = stored
+		}
+	}
+	info, ok := arenas[val]
+	return ok && info.readers
+}
+
+// callsAcquireRead reports whether fn calls Arena.AcquireRead or
+// Arena.TryAcquireRead. The check is intra-procedural: read access taken in
+// a function that fn calls is not seen.
+func callsAcquireRead(fn *ssa.Function) bool {
+	if fn == nil {
+		return false
+	}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+			callee := call.Call.StaticCallee()
+			if callee == nil || callee.Signature.Recv() == nil || !isSafeArenaPtr(callee.Signature.Recv().Type()) {
+				continue
+			}
+			if name := callee.Name(); name == "AcquireRead" || name == "TryAcquireRead" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containerEscape follows a map, slice, or array value forward through the
+// function and describes how it escapes ("via return", "to global variable"),
+// or returns "" if it stays local. The analysis is intra-procedural: passing
+// the container to another function is not treated as an escape.
+func containerEscape(container ssa.Value) string {
+	visited := make(map[ssa.Value]bool)
+	work := []ssa.Value{container}
+
+	for len(work) > 0 {
+		val := work[len(work)-1]
+		work = work[:len(work)-1]
+		if visited[val] {
+			continue
+		}
+		visited[val] = true
+
+		refs := val.Referrers()
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			switch r := ref.(type) {
+			case *ssa.Return:
+				return "via return"
+
+			case *ssa.Store:
+				if r.Val != val {
+					continue
+				}
+				if isGlobalVar(r.Addr) {
+					return "to global variable"
+				}
+				// Stored into a local or a field/element: follow the enclosing value
+				switch addr := r.Addr.(type) {
+				case *ssa.FieldAddr:
+					work = append(work, addr.X)
+				case *ssa.IndexAddr:
+					work = append(work, addr.X)
+				default:
+					work = append(work, addr)
+				}
+
+			case *ssa.UnOp:
+				// Load from a local holding the container
+				if r.Op == token.MUL {
+					work = append(work, r)
+				}
+
+			case *ssa.Slice, *ssa.Phi, *ssa.MakeInterface, *ssa.ChangeType:
+				work = append(work, r.(ssa.Value))
+
+			case *ssa.Call:
+				// append(container, ...) yields the same backing storage
+				if b, ok := r.Call.Value.(*ssa.Builtin); ok && b.Name() == "append" {
+					work = append(work, r)
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
 func isPointerType(t types.Type) bool {
 	switch t := t.(type) {
 	case *types.Pointer:
@@ -209,8 +624,9 @@ func isGlobalVar(val ssa.Value) bool {
 	return ok
 }
 
-// checkUseAfterFree detects if an instruction uses an allocation after its arena was freed
-func checkUseAfterFree(pass *analysis.Pass, instr ssa.Instruction, allocations map[ssa.Value]*allocInfo, freedArenas map[ssa.Value]bool, storesTo map[ssa.Value]ssa.Value) {
+// checkUseAfterFree detects if an instruction uses an allocation after its
+// arena was freed, and reports whether it did
+func checkUseAfterFree(pass *analysis.Pass, instr ssa.Instruction, allocations map[ssa.Value]*allocInfo, freedArenas map[ssa.Value]bool, storesTo map[ssa.Value]ssa.Value) bool {
 	// Get all operands of this instruction
 	operandPtrs := instr.Operands(nil)
 
@@ -227,10 +643,144 @@ func checkUseAfterFree(pass *analysis.Pass, instr ssa.Instruction, allocations m
 				pass.Reportf(instr.Pos(),
 					"use of arena allocation after Free() (allocated at %s)",
 					alloc.allocPos)
-				return // Only report once per instruction
+				return true // Only report once per instruction
+			}
+		}
+	}
+	return false
+}
+
+// checkUseAfterMayFree detects uses of an allocation whose arena was freed on
+// some path to the instruction. A Free whose branch condition contradicts
+// one that guards the use (`if done { a.Free() }` then `if !done { use }`)
+// cannot have run and is ignored.
+func checkUseAfterMayFree(pass *analysis.Pass, instr ssa.Instruction, allocations map[ssa.Value]*allocInfo, mayFree map[ssa.Instruction]bool, freeInstrs map[ssa.Instruction]ssa.Value, storesTo map[ssa.Value]ssa.Value) {
+	var useGuards []branchGuard // Computed on first need
+	for _, operandPtr := range instr.Operands(nil) {
+		if operandPtr == nil || *operandPtr == nil {
+			continue
+		}
+		alloc := findAllocation(*operandPtr, allocations, storesTo)
+		if alloc == nil {
+			continue
+		}
+		for free := range mayFree {
+			if freeInstrs[free] != alloc.arena.value {
+				continue
+			}
+			if useGuards == nil {
+				useGuards = blockGuards(instr.Block())
+			}
+			if contradicts(blockGuards(free.Block()), useGuards) {
+				continue
+			}
+			pass.Reportf(instr.Pos(),
+				"use of arena allocation after Free() on some paths (allocated at %s, freed at %s)",
+				alloc.allocPos, pass.Fset.Position(free.Pos()))
+			return // Only report once per instruction
+		}
+	}
+}
+
+// branchGuard is the outcome of an if statement's condition
+type branchGuard struct {
+	cond  ssa.Value
+	taken bool // true for the then-branch
+}
+
+// blockGuards returns the branch outcomes that hold whenever block runs: one
+// for each if statement with a branch that every path to block goes through
+func blockGuards(block *ssa.BasicBlock) []branchGuard {
+	guards := []branchGuard{}
+	for b := block; b.Idom() != nil; b = b.Idom() {
+		p := b.Idom()
+		if len(b.Preds) != 1 || b.Preds[0] != p || len(p.Instrs) == 0 {
+			continue
+		}
+		if branch, ok := p.Instrs[len(p.Instrs)-1].(*ssa.If); ok {
+			guards = append(guards, branchGuard{cond: branch.Cond, taken: b == p.Succs[0]})
+		}
+	}
+	return guards
+}
+
+// contradicts reports whether some guard in a is the opposite outcome of a
+// guard in b, so code guarded by a and code guarded by b never both run
+func contradicts(a, b []branchGuard) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.cond == y.cond && x.taken != y.taken {
+				return true
 			}
 		}
 	}
+	return false
+}
+
+// mayFreeAtEntry computes, for each block, the Free calls that ran on at
+// least one path from the function entry to the start of the block. Creating
+// an arena again (in a loop) discards the earlier Frees of that arena.
+func mayFreeAtEntry(fn *ssa.Function, freeInstrs map[ssa.Instruction]ssa.Value, arenas map[ssa.Value]*arenaInfo) map[*ssa.BasicBlock]map[ssa.Instruction]bool {
+	in := make(map[*ssa.BasicBlock]map[ssa.Instruction]bool)
+	if len(freeInstrs) == 0 {
+		return in
+	}
+
+	work := []*ssa.BasicBlock{fn.Blocks[0]}
+	visited := make(map[*ssa.BasicBlock]bool)
+	for len(work) > 0 {
+		block := work[len(work)-1]
+		work = work[:len(work)-1]
+		visited[block] = true
+
+		out := copyFreeSet(in[block])
+		for _, instr := range block.Instrs {
+			if _, ok := freeInstrs[instr]; ok {
+				out[instr] = true
+			}
+			killFrees(out, instr, freeInstrs, arenas)
+		}
+
+		for _, succ := range block.Succs {
+			if in[succ] == nil {
+				in[succ] = make(map[ssa.Instruction]bool)
+			}
+			changed := false
+			for free := range out {
+				if !in[succ][free] {
+					in[succ][free] = true
+					changed = true
+				}
+			}
+			if changed || !visited[succ] {
+				work = append(work, succ)
+			}
+		}
+	}
+	return in
+}
+
+// killFrees removes from set the Frees of the arena created by instr, if
+// instr creates one
+func killFrees(set map[ssa.Instruction]bool, instr ssa.Instruction, freeInstrs map[ssa.Instruction]ssa.Value, arenas map[ssa.Value]*arenaInfo) {
+	v, ok := instr.(ssa.Value)
+	if !ok || arenas[v] == nil {
+		return
+	}
+	for free := range set {
+		if freeInstrs[free] == v {
+			delete(set, free)
+		}
+	}
+}
+
+// copyFreeSet returns a copy of set
+func copyFreeSet(set map[ssa.Instruction]bool) map[ssa.Instruction]bool {
+	c := make(map[ssa.Instruction]bool, len(set))
+	for free := range set {
+		c[free] = true
+	}
+	return c
 }
 
 func debugValue(val ssa.Value) string {
@@ -0,0 +1,75 @@
+package testdata
+
+// Collections holding SafeArena values.
+// This file uses the safearena wrapper API, so run it from the repository root:
+//
+//	GOEXPERIMENT=arenas arenacheck ./cmd/arenacheck/testdata/collections.go
+
+import "github.com/scttfrdmn/safearena"
+
+type Node struct {
+	Value int
+}
+
+// BAD: Map of arena pointers is returned
+func badMapReturn() map[string]safearena.Ptr[Node] {
+	a := safearena.New()
+	defer a.Free()
+
+	nodes := make(map[string]safearena.Ptr[Node])
+	nodes["root"] = safearena.Alloc(a, Node{Value: 1}) // want "arena value stored in map escapes via return"
+	return nodes
+}
+
+// BAD: Slice of arena slices is appended to and returned
+func badSliceAppendReturn() []safearena.Slice[byte] {
+	a := safearena.New()
+	defer a.Free()
+
+	var buffers []safearena.Slice[byte]
+	for i := 0; i < 3; i++ {
+		buffers = append(buffers, safearena.AllocSlice[byte](a, 64)) // want "arena value stored in slice escapes via return"
+	}
+	return buffers
+}
+
+// BAD: Slice of arena pointers is stored to a global
+var globalNodes []safearena.Ptr[Node]
+
+func badSliceGlobal() {
+	a := safearena.New()
+	defer a.Free()
+
+	nodes := make([]safearena.Ptr[Node], 2)
+	nodes[0] = safearena.Alloc(a, Node{Value: 1}) // want "arena value stored in slice escapes to global variable"
+	globalNodes = nodes
+}
+
+// GOOD: Collection of arena pointers stays local
+func goodLocalCollection() int {
+	a := safearena.New()
+	defer a.Free()
+
+	nodes := make(map[string]safearena.Ptr[Node])
+	nodes["root"] = safearena.Alloc(a, Node{Value: 1})
+
+	var list []safearena.Ptr[Node]
+	list = append(list, nodes["root"])
+
+	sum := 0
+	for _, n := range list {
+		sum += n.Get().Value
+	}
+	return sum
+}
+
+// GOOD: Values are cloned to the heap before the collection escapes
+func goodClonedCollection() map[string]*Node {
+	a := safearena.New()
+	defer a.Free()
+
+	result := make(map[string]*Node)
+	p := safearena.Alloc(a, Node{Value: 1})
+	result["root"] = safearena.Clone(p)
+	return result
+}
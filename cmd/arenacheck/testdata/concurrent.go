@@ -0,0 +1,68 @@
+package testdata
+
+// Goroutines sharing arenas built for concurrent use.
+// This file uses the safearena wrapper API, so run it from the repository root:
+//
+//	GOEXPERIMENT=arenas arenacheck ./cmd/arenacheck/testdata/concurrent.go
+
+import (
+	"sync"
+
+	"github.com/scttfrdmn/safearena"
+)
+
+type Job struct {
+	ID int
+}
+
+func serve(j *Job) {}
+
+// GOOD: Goroutines allocate from a SharedArena, as NewShared documents
+func goodSharedArena(jobs []Job) {
+	s := safearena.NewShared()
+	defer s.Free()
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			safearena.SharedAlloc(s, job)
+		}()
+	}
+	wg.Wait()
+}
+
+// GOOD: Readers hold AcquireRead on a NewConcurrent arena, so Free waits
+func goodConcurrentReaders() {
+	a := safearena.NewConcurrent()
+	job := safearena.Alloc(a, Job{ID: 1})
+	for range 4 {
+		go func() {
+			release := a.AcquireRead()
+			defer release()
+			serve(job.Get())
+		}()
+	}
+	a.Free()
+}
+
+// BAD: A plain arena shared with a goroutine has no reader lock
+func badPlainArenaReader() {
+	a := safearena.New()
+	job := safearena.Alloc(a, Job{ID: 1})
+	go func() { // want "arena value passed to goroutine may be used after Free()"
+		serve(job.Get())
+	}()
+	a.Free()
+}
+
+// BAD: A NewConcurrent arena only protects readers that hold AcquireRead
+func badConcurrentWithoutAcquire() {
+	a := safearena.NewConcurrent()
+	job := safearena.Alloc(a, Job{ID: 1})
+	go func() { // want "arena value passed to goroutine may be used after Free()"
+		serve(job.Get())
+	}()
+	a.Free()
+}
@@ -0,0 +1,89 @@
+package testdata
+
+import "arena"
+
+type Record struct {
+	Value int
+}
+
+func log(v int) {}
+
+// BAD: Free in an earlier block, use in a later block on the same path
+func badUseAfterLoop(n int) int {
+	a := arena.NewArena()
+	r := arena.New[Record](a)
+	a.Free()
+
+	for i := 0; i < n; i++ {
+		log(i)
+	}
+	return r.Value // want "use of arena allocation after Free()"
+}
+
+// BAD: Free before a branch; the use after the branch is dominated by it
+func badUseAfterBranch(verbose bool) int {
+	a := arena.NewArena()
+	r := arena.New[Record](a)
+	r.Value = 1
+	a.Free()
+
+	if verbose {
+		log(0)
+	}
+	return r.Value // want "use of arena allocation after Free()"
+}
+
+// BAD: Free happens only when the if is taken, but the use is unconditional,
+// so it reads freed memory whenever done is true
+func badConditionalFreeThenUse(done bool) int {
+	a := arena.NewArena()
+	r := arena.New[Record](a)
+	if done {
+		a.Free()
+	}
+	return r.Value // want "use of arena allocation after Free\(\) on some paths"
+}
+
+// GOOD: the use is guarded by the opposite of the condition that freed
+func goodSameConditionGuard(done bool) {
+	a := arena.NewArena()
+	r := arena.New[Record](a)
+	r.Value = 1
+	if done {
+		a.Free()
+	}
+	if !done {
+		log(r.Value)
+		a.Free()
+	}
+}
+
+// GOOD: each iteration frees the arena it created; the Free from the
+// previous iteration does not reach this iteration's allocation
+func goodFreeEachIteration(n int) int {
+	sum := 0
+	for i := 0; i < n; i++ {
+		a := arena.NewArena()
+		r := arena.New[Record](a)
+		r.Value = i
+		sum += r.Value
+		a.Free()
+	}
+	return sum
+}
+
+// GOOD: Free on one branch, use only on the other
+func goodBranchLocalFree(done bool) int {
+	a := arena.NewArena()
+	defer func() {
+		if !done {
+			a.Free()
+		}
+	}()
+	r := arena.New[Record](a)
+	if done {
+		a.Free()
+		return 0
+	}
+	return r.Value
+}
@@ -0,0 +1,75 @@
+package testdata
+
+// Goroutines that receive arena values.
+// This file uses the safearena wrapper API, so run it from the repository root:
+//
+//	GOEXPERIMENT=arenas arenacheck ./cmd/arenacheck/testdata/goroutine.go
+
+import (
+	"arena"
+	"net/http"
+
+	"github.com/scttfrdmn/safearena"
+)
+
+type Event struct {
+	ID int
+}
+
+func record(e *Event) {}
+
+// BAD: Handler spawns a goroutine that uses an arena pointer after the
+// deferred Free may have run
+func badHandlerGoroutine(w http.ResponseWriter, r *http.Request) {
+	a := safearena.New()
+	defer a.Free()
+
+	ev := safearena.Alloc(a, Event{ID: 1})
+	go func() { // want "arena value passed to goroutine may be used after Free()"
+		record(ev.Get())
+	}()
+}
+
+// BAD: Arena slice passed as a goroutine argument
+func badGoroutineArg() {
+	a := safearena.New()
+	defer a.Free()
+
+	buf := safearena.AllocSlice[byte](a, 64)
+	go func(b safearena.Slice[byte]) { // want "arena value passed to goroutine may be used after Free()"
+		_ = b.Get()
+	}(buf)
+}
+
+// BAD: Goroutine captures the arena itself
+func badGoroutineArena() {
+	a := safearena.New()
+	defer a.Free()
+
+	go func() { // want "arena value passed to goroutine may be used after Free()"
+		_ = safearena.Alloc(a, Event{ID: 2})
+	}()
+}
+
+// BAD: Goroutine captures a raw arena allocation
+func badGoroutineRaw() {
+	a := arena.NewArena()
+	defer a.Free()
+
+	ev := arena.New[Event](a)
+	go func() { // want "arena value passed to goroutine may be used after Free()"
+		record(ev)
+	}()
+}
+
+// GOOD: Goroutine only uses data cloned to the heap
+func goodGoroutineClone() {
+	a := safearena.New()
+	defer a.Free()
+
+	ev := safearena.Alloc(a, Event{ID: 1})
+	heapEv := safearena.Clone(ev)
+	go func() {
+		record(heapEv)
+	}()
+}
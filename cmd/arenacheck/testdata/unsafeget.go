@@ -0,0 +1,83 @@
+package testdata
+
+// UnsafeGet and UnsafeAlloc results escaping the arena scope.
+// This file uses the safearena wrapper API, so run it from the repository root:
+//
+//	GOEXPERIMENT=arenas arenacheck ./cmd/arenacheck/testdata/unsafeget.go
+
+import "github.com/scttfrdmn/safearena"
+
+var lastFrame []byte
+
+// BAD: UnsafeGet slice is returned after the deferred Free
+func badUnsafeGetReturn() []byte {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	buf := safearena.AllocSliceOpt[byte](a, 64)
+	return buf.UnsafeGet() // want "UnsafeGet result escapes via return"
+}
+
+// BAD: Subslice of an UnsafeGet result is returned
+func badUnsafeGetSubslice(n int) []byte {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	raw := safearena.AllocSliceOpt[byte](a, 64).UnsafeGet()
+	return raw[:n] // want "UnsafeGet result escapes via return"
+}
+
+// BAD: UnsafeGet slice is stored in a global
+func badUnsafeGetGlobal() {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	lastFrame = safearena.AllocSliceOpt[byte](a, 64).UnsafeGet() // want "UnsafeGet result escapes to global variable"
+}
+
+// GOOD: UnsafeGet slice is used only while the arena is alive
+func goodUnsafeGetLocal() int {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	raw := safearena.AllocSliceOpt[byte](a, 64).UnsafeGet()
+	copy(raw, "header")
+	sum := 0
+	for _, b := range raw {
+		sum += int(b)
+	}
+	return sum // Only a copied value escapes
+}
+
+type node struct {
+	Value int
+}
+
+// BAD: UnsafeAlloc pointer is returned after the deferred Free
+func badUnsafeAllocReturn() *node {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	return safearena.UnsafeAlloc(a, node{Value: 1}) // want "escapes via return"
+}
+
+// BAD: UnsafeAlloc pointer is used after Free
+func badUnsafeAllocAfterFree() int {
+	a := safearena.NewOpt()
+	n := safearena.UnsafeAlloc(a, node{Value: 1})
+	a.Free()
+	return n.Value // want "use of arena allocation after Free()"
+}
+
+// GOOD: UnsafeAlloc pointers stay inside the loop that owns the arena
+func goodUnsafeAllocLoop(values []int) int {
+	a := safearena.NewOpt()
+	defer a.Free()
+
+	sum := 0
+	for _, v := range values {
+		n := safearena.UnsafeAlloc(a, node{Value: v})
+		sum += n.Value
+	}
+	return sum
+}
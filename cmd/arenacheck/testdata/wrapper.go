@@ -0,0 +1,71 @@
+package testdata
+
+// The safearena wrapper API: safearena.New, Alloc, Free, and Scoped.
+// This file uses the safearena wrapper API, so run it from the repository root:
+//
+//	GOEXPERIMENT=arenas arenacheck ./cmd/arenacheck/testdata/wrapper.go
+
+import "github.com/scttfrdmn/safearena"
+
+type Config struct {
+	Port int
+}
+
+var current safearena.Ptr[Config]
+
+// BAD: Ptr is returned after the deferred Free
+func badReturnPtr() safearena.Ptr[Config] {
+	a := safearena.New()
+	defer a.Free()
+
+	cfg := safearena.Alloc(a, Config{Port: 8080})
+	return cfg // want "arena-allocated value escapes via return"
+}
+
+// BAD: Slice is returned after the deferred Free
+func badReturnSlice() safearena.Slice[byte] {
+	a := safearena.NewNamed("decoder")
+	defer a.Free()
+
+	return safearena.AllocSlice[byte](a, 64) // want "arena-allocated value escapes via return"
+}
+
+// BAD: Ptr is stored in a global
+func badGlobalPtr() {
+	a := safearena.New()
+	defer a.Free()
+
+	current = safearena.Alloc(a, Config{}) // want "arena-allocated value escapes to global variable"
+}
+
+// BAD: Ptr is used after Free
+func badUseAfterFree() int {
+	a := safearena.New()
+	cfg := safearena.Alloc(a, Config{Port: 8080})
+	a.Free()
+
+	return cfg.Get().Port // want "use of arena allocation after Free()"
+}
+
+// BAD: Scoped callback returns a Ptr that outlives the scope
+func badScopedReturn() safearena.Ptr[Config] {
+	return safearena.Scoped(func(a *safearena.Arena) safearena.Ptr[Config] {
+		return safearena.Alloc(a, Config{}) // want "arena-allocated value escapes via return"
+	})
+}
+
+// GOOD: Value is cloned to the heap before Free
+func goodClone() *Config {
+	a := safearena.New()
+	defer a.Free()
+
+	cfg := safearena.Alloc(a, Config{Port: 8080})
+	return safearena.Clone(cfg)
+}
+
+// GOOD: Scoped callback returns a heap value
+func goodScopedValue() int {
+	return safearena.Scoped(func(a *safearena.Arena) int {
+		return safearena.Alloc(a, Config{Port: 8080}).Get().Port
+	})
+}
@@ -0,0 +1,67 @@
+package safearena
+
+import "sync"
+
+// NewConcurrent creates an arena whose values can be read from several
+// goroutines while another goroutine may free it. Readers bracket their
+// accesses with AcquireRead, and Free, FreeAsync, and Reset wait until every
+// reader has released, so a reader can never observe the arena being freed
+// underneath it.
+//
+// Plain arenas already allow concurrent reads (Get is two atomic loads),
+// but nothing stops Free from running in the middle of one; NewConcurrent is
+// for the case where that race cannot be ruled out by program structure.
+// Allocation is not made concurrent-safe; use SharedArena for that.
+//
+// Example:
+//
+//	a := safearena.NewConcurrent()
+//	index := buildIndex(a)
+//	for range workers {
+//	    go func() {
+//	        release := a.AcquireRead()
+//	        defer release()
+//	        serve(index.Get())
+//	    }()
+//	}
+//	a.Free() // Waits for readers holding AcquireRead
+func NewConcurrent() *Arena {
+	a := New()
+	a.readers = new(sync.RWMutex)
+	return a
+}
+
+// AcquireRead blocks Free, FreeAsync, and Reset until the returned release
+// function is called, so values read in between stay valid. Any number of
+// goroutines may hold read access at once. Each release function must be
+// called exactly once, and a goroutine holding read access must not free or
+// reset the arena itself, or it deadlocks.
+//
+// Panics if the arena was not created with NewConcurrent or NewWithTTL, or
+// if it has already been freed.
+func (a *Arena) AcquireRead() (release func()) {
+	release, ok := a.TryAcquireRead()
+	if !ok {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "use after free", stack, UseAfterFree))
+	}
+	return release
+}
+
+// TryAcquireRead is like AcquireRead but reports false instead of panicking
+// if the arena has already been freed, for arenas that may be freed by
+// another goroutine at any time, such as a NewWithTTL arena that may have
+// expired.
+//
+// Panics if the arena was not created with NewConcurrent or NewWithTTL.
+func (a *Arena) TryAcquireRead() (release func(), ok bool) {
+	if a.readers == nil {
+		panic("safearena: AcquireRead on an arena not created with NewConcurrent or NewWithTTL")
+	}
+	a.readers.RLock()
+	if a.freed.Load() {
+		a.readers.RUnlock()
+		return nil, false
+	}
+	return a.readers.RUnlock, true
+}
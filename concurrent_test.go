@@ -0,0 +1,118 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewConcurrentFreeWaitsForReaders(t *testing.T) {
+	for name, free := range map[string]func(*Arena){
+		"Free":      (*Arena).Free,
+		"FreeAsync": (*Arena).FreeAsync,
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := NewConcurrent()
+			p := Alloc(a, 42)
+
+			release := a.AcquireRead()
+			freed := make(chan struct{})
+			go func() {
+				free(a)
+				close(freed)
+			}()
+
+			select {
+			case <-freed:
+				t.Fatal("expected free to wait for the reader")
+			case <-time.After(20 * time.Millisecond):
+			}
+			if p.Deref() != 42 {
+				t.Errorf("expected 42 while holding read access, got %d", p.Deref())
+			}
+			release()
+
+			select {
+			case <-freed:
+			case <-time.After(time.Second):
+				t.Fatal("expected free to proceed after release")
+			}
+		})
+	}
+}
+
+// TestNewConcurrentRace is meant for the race detector: readers and a freer
+// run concurrently, and every read happens under AcquireRead
+func TestNewConcurrentRace(t *testing.T) {
+	a := NewConcurrent()
+	values := AllocSlice[int](a, 64)
+	values.Fill(7)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if !read(t, a, values) {
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(5 * time.Millisecond)
+	a.Free()
+	wg.Wait()
+}
+
+// read sums values under read access, reporting false once the arena is freed
+func read(t *testing.T, a *Arena, values Slice[int]) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if msg := fmt.Sprint(r); !strings.Contains(msg, "use after free") {
+				t.Errorf("expected use after free panic, got %q", msg)
+			}
+			ok = false
+		}
+	}()
+	release := a.AcquireRead()
+	defer release()
+	sum := 0
+	for _, v := range values.Get() {
+		sum += v
+	}
+	if sum != 7*64 {
+		t.Errorf("expected sum %d, got %d", 7*64, sum)
+	}
+	return true
+}
+
+func TestAcquireReadMisuse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		acquire func()
+		want    string
+	}{
+		"plain arena": {func() {
+			a := New()
+			defer a.Free()
+			a.AcquireRead()
+		}, "not created with NewConcurrent or NewWithTTL"},
+		"freed": {func() {
+			a := NewConcurrent()
+			a.Free()
+			a.AcquireRead()
+		}, "use after free"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.acquire()
+		})
+	}
+}
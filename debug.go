@@ -0,0 +1,259 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Debug enables diagnostic instrumentation for arenas created while it is set.
+// Instrumentation adds overhead to every operation, so leave it off in production
+// and enable it in tests or while troubleshooting:
+//
+//	safearena.Debug = true
+//	defer func() { safearena.Debug = false }()
+//
+// Arenas capture the setting when they are created; toggling Debug does not
+// affect arenas that already exist.
+//
+// Debug-mode arenas additionally:
+//   - accumulate operation timings (see OperationTimings)
+//   - record where each value was allocated, so use-after-free panics report
+//     both the allocation site and the access site
+//   - verify on Ptr.Get that the pointer was allocated from the Ptr's arena,
+//     an integrity check that catches bugs inside this package (a Ptr paired
+//     with the wrong arena) rather than misuse by callers
+//   - support allocation snapshots (see Arena.Snapshot and DiffSnapshots)
+//   - enforce Freeze on Ptr and Slice values
+//   - poison freed memory (see Arena.Free)
+//   - record Detach calls (see Arena.Detached)
+//   - with CheckAliases, verify on Free that no detached copy points into them
+//   - warn when code inside a nested Scoped call allocates into the arena of
+//     an outer scope rather than the innermost one
+//   - are listed by LiveArenas until freed
+//
+// NewWithTracer creates a debug-mode arena regardless of Debug.
+var Debug bool
+
+// debugState holds per-arena diagnostics for debug-mode arenas.
+// It is kept after Free so post-free accesses can still be diagnosed.
+type debugState struct {
+	mu     sync.Mutex
+	allocs map[uintptr]*allocRecord // keyed by allocation address
+
+	detached     []*detachRecord // Detach calls, in order
+	checkAliases bool            // CheckAliases was set at creation
+
+	tracing bool           // Created by NewWithTracer
+	trace   []*allocRecord // Every allocation when tracing, in order; survives Reset
+
+	created time.Time  // For LiveArenas
+	site    *stackInfo // Stack at creation, for LiveArenas
+
+	sizes [65]int // Allocations per sizeBucket since creation or Reset
+
+	frozen int // Allocations frozen with Freeze; isFrozen skips the lookup while 0
+}
+
+// allocRecord describes a single debug-mode allocation
+type allocRecord struct {
+	ptr   unsafe.Pointer
+	site  *stackInfo
+	typ   reflect.Type // []E for slice allocations
+	size  uintptr      // Bytes allocated
+	gen   uint64       // Arena generation at allocation
+	slice bool         // Allocation holds size/E.Size() elements of typ []E
+
+	frozen bool // Set by Freeze; guarded by debugState.mu
+}
+
+func newDebugState() *debugState {
+	return &debugState{
+		allocs: make(map[uintptr]*allocRecord),
+	}
+}
+
+// record registers the allocation at ptr
+func (d *debugState) record(ptr unsafe.Pointer, rec *allocRecord) {
+	rec.ptr = ptr
+	d.mu.Lock()
+	d.allocs[uintptr(ptr)] = rec
+	d.sizes[sizeBucket(rec.size)]++
+	if d.tracing {
+		d.trace = append(d.trace, rec)
+	}
+	d.mu.Unlock()
+}
+
+// recordAlloc registers a debug-mode allocation of size bytes of type typ at
+// ptr, made from site
+func (a *Arena) recordAlloc(ptr unsafe.Pointer, site *stackInfo, typ reflect.Type, size uintptr) {
+	a.checkScope(site)
+	a.debug.record(ptr, &allocRecord{
+		site: site,
+		typ:  typ,
+		size: size,
+		gen:  a.gen.Load(),
+	})
+}
+
+// recordSliceAlloc is like recordAlloc for a slice allocation: typ is the
+// slice type and size covers all of its elements
+func (a *Arena) recordSliceAlloc(ptr unsafe.Pointer, site *stackInfo, typ reflect.Type, size uintptr) {
+	a.checkScope(site)
+	a.debug.record(ptr, &allocRecord{
+		site:  site,
+		typ:   typ,
+		size:  size,
+		gen:   a.gen.Load(),
+		slice: true,
+	})
+}
+
+// lookup returns the record for the allocation at ptr, or nil
+func (d *debugState) lookup(ptr unsafe.Pointer) *allocRecord {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.allocs[uintptr(ptr)]
+}
+
+// freeze marks the allocation containing ptr immutable, so that freezing a
+// view (see Slice.Slice) freezes the whole allocation. Records of generations
+// below floor are ignored. It reports false if the allocation is unknown.
+func (d *debugState) freeze(ptr unsafe.Pointer, floor uint64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rec := d.containing(uintptr(ptr), floor)
+	if rec == nil {
+		return false
+	}
+	if !rec.frozen {
+		rec.frozen = true
+		d.frozen++
+	}
+	return true
+}
+
+// isFrozen reports whether the allocation containing ptr has been frozen
+func (d *debugState) isFrozen(ptr unsafe.Pointer, floor uint64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen == 0 {
+		return false
+	}
+	rec := d.containing(uintptr(ptr), floor)
+	return rec != nil && rec.frozen
+}
+
+// allocSite returns where the value at ptr was allocated, or nil if the arena
+// is not in debug mode or the allocation is unknown
+func (a *Arena) allocSite(ptr unsafe.Pointer) *stackInfo {
+	if a.debug == nil {
+		return nil
+	}
+	if rec := a.debug.lookup(ptr); rec != nil {
+		return rec.site
+	}
+	return nil
+}
+
+// checkOwner verifies, for debug-mode arenas, that ptr lies in an allocation
+// recorded by a. Every Ptr the package hands out is built from an allocation
+// of its own arena, so a failure means the package itself paired a pointer
+// with the wrong arena (or the Ptr was corrupted through unsafe code), and
+// liveness checks would consult the wrong arena. Ptr has no room to carry
+// the owning arena's id (see ptrSize); the allocation records serve as the
+// stamp instead.
+func (a *Arena) checkOwner(ptr unsafe.Pointer) {
+	if a.debug.lookup(ptr) != nil || a.debug.find(uintptr(ptr), a.floor.Load()) != nil {
+		return
+	}
+	panic(fmt.Sprintf("safearena: internal error: Ptr bound to %s points to %p, which was not allocated from it; please report this bug",
+		arenaLabel(a.id, a.name), ptr))
+}
+
+// Contains reports whether ptr points into memory allocated from the arena.
+// It answers "is this pointer arena-backed?" when arena and heap data are
+// mixed, including after Free, when the answer decides whether a raw pointer
+// is still safe to use.
+//
+// Go arenas do not expose their address ranges, so Contains checks the
+// ranges recorded in debug mode (see Debug): allocations through Alloc,
+// AllocSlice, AllocBytes, and the other functions that record an allocation
+// site. Buffers grown internally by writers and containers are not covered.
+// For arenas created without Debug, Contains always reports false.
+//
+// Contains scans every recorded allocation; it is meant for debugging, not
+// hot paths.
+func (a *Arena) Contains(ptr unsafe.Pointer) bool {
+	if a.debug == nil || ptr == nil {
+		return false
+	}
+
+	addr := uintptr(ptr)
+	a.debug.mu.Lock()
+	defer a.debug.mu.Unlock()
+	for start, rec := range a.debug.allocs {
+		if addr >= start && addr-start < rec.size {
+			return true
+		}
+	}
+	return false
+}
+
+// Operation identifiers for debug-mode timing
+const (
+	opNew = iota
+	opAlloc
+	opAllocSlice
+	opFree
+	numOps
+)
+
+var opNames = [numOps]string{
+	opNew:        "New",
+	opAlloc:      "Alloc",
+	opAllocSlice: "AllocSlice",
+	opFree:       "Free",
+}
+
+// opTimings accumulates nanoseconds spent per operation across the process
+var opTimings [numOps]atomic.Int64
+
+// recordTiming adds the time elapsed since start to the operation's total.
+// Intended for use with defer: defer recordTiming(opAlloc, time.Now())
+func recordTiming(op int, start time.Time) {
+	opTimings[op].Add(int64(time.Since(start)))
+}
+
+// OperationTimings returns the cumulative time spent in New, Alloc, AllocSlice,
+// and Free by debug-mode arenas since process start or the last call to
+// ResetOperationTimings. Keys are the operation names.
+//
+// Only arenas created while Debug is set are timed, because timing costs two
+// time.Now() calls per operation.
+//
+// Example:
+//
+//	safearena.Debug = true
+//	runWorkload()
+//	for op, d := range safearena.OperationTimings() {
+//	    fmt.Printf("%-10s %v\n", op, d)
+//	}
+func OperationTimings() map[string]time.Duration {
+	timings := make(map[string]time.Duration, numOps)
+	for op := range opTimings {
+		timings[opNames[op]] = time.Duration(opTimings[op].Load())
+	}
+	return timings
+}
+
+// ResetOperationTimings clears the accumulated operation timings.
+func ResetOperationTimings() {
+	for op := range opTimings {
+		opTimings[op].Store(0)
+	}
+}
@@ -0,0 +1,195 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// enableDebug turns on Debug for the duration of a test
+func enableDebug(t *testing.T) {
+	t.Helper()
+	Debug = true
+	t.Cleanup(func() { Debug = false })
+}
+
+func TestOperationTimings(t *testing.T) {
+	enableDebug(t)
+	ResetOperationTimings()
+
+	const rounds = 100
+	for i := 0; i < rounds; i++ {
+		a := New()
+		for j := 0; j < 10; j++ {
+			_ = Alloc(a, j)
+			_ = AllocSlice[byte](a, 64)
+		}
+		a.Free()
+	}
+
+	timings := OperationTimings()
+	for _, op := range []string{"New", "Alloc", "AllocSlice", "Free"} {
+		d, ok := timings[op]
+		if !ok {
+			t.Errorf("missing timing for %s", op)
+			continue
+		}
+		if d <= 0 {
+			t.Errorf("expected non-zero timing for %s, got %v", op, d)
+		}
+	}
+
+	ResetOperationTimings()
+	for op, d := range OperationTimings() {
+		if d != 0 {
+			t.Errorf("expected %s timing to be reset, got %v", op, d)
+		}
+	}
+}
+
+func TestOperationTimingsDisabled(t *testing.T) {
+	ResetOperationTimings()
+
+	a := New()
+	_ = Alloc(a, 1)
+	_ = AllocSlice[int](a, 8)
+	a.Free()
+
+	for op, d := range OperationTimings() {
+		if d != 0 {
+			t.Errorf("expected no %s timing without Debug, got %v", op, d)
+		}
+	}
+}
+
+func TestDebugAllocationSite(t *testing.T) {
+	enableDebug(t)
+
+	tests := []struct {
+		name  string
+		setup func(a *Arena) func()
+	}{
+		{"ptr", func(a *Arena) func() {
+			p := Alloc(a, 42) // allocation site under test
+			return func() { _ = p.Get() }
+		}},
+		{"slice", func(a *Arena) func() {
+			s := AllocSlice[byte](a, 16) // allocation site under test
+			return func() { _ = s.Get() }
+		}},
+		{"alloc n", func(a *Arena) func() {
+			ptrs := AllocN[int](a, 4) // allocation site under test
+			return func() { _ = ptrs[2].Get() }
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			use := tt.setup(a)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "allocated at debug_test.go") {
+					t.Errorf("expected allocation site in message, got: %s", msg)
+				}
+				if !strings.Contains(msg, "accessed at debug_test.go") {
+					t.Errorf("expected access site in message, got: %s", msg)
+				}
+				if !strings.Contains(msg, "TestDebugAllocationSite") {
+					t.Errorf("expected test function in message, got: %s", msg)
+				}
+			}()
+			use()
+		})
+	}
+}
+
+func TestNoAllocationSiteWithoutDebug(t *testing.T) {
+	a := New()
+	p := Alloc(a, 42)
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if strings.Contains(msg, "allocated at") {
+			t.Errorf("expected no allocation site without Debug, got: %s", msg)
+		}
+	}()
+	_ = p.Get()
+}
+
+func TestDebugPtrOwnerCheck(t *testing.T) {
+	enableDebug(t)
+
+	a, b := New(), New()
+	defer a.Free()
+	defer b.Free()
+
+	// Every way of obtaining a Ptr passes the check, including pointers
+	// into the middle of a batch allocation
+	var ptrs PtrSlice[int]
+	ptrs.Append(a, 1)
+	pool := NewObjectPool[int](a, 2)
+	for _, p := range append([]Ptr[int]{Alloc(a, 1), ptrs.At(0), pool.Acquire(), pool.Acquire()}, AllocN[int](a, 3)...) {
+		p.Get()
+	}
+	src := New()
+	for _, p := range Merge(a, src, Alloc(src, 1), Alloc(src, 2)) {
+		p.Get()
+	}
+
+	misbound := Ptr[int]{ptr: Alloc(b, 2).ptr, arena: a, gen: a.gen.Load()}
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "internal error: Ptr bound to arena") {
+			t.Errorf("expected internal error panic, got %q", msg)
+		}
+	}()
+	misbound.Get()
+}
+
+func TestContains(t *testing.T) {
+	enableDebug(t)
+	a, b := New(), New()
+	defer b.Free()
+
+	type pair struct{ X, Y int64 }
+	p := Alloc(a, pair{})
+	s := AllocSlice[int32](a, 8)
+	heap := new(pair)
+	other := Alloc(b, pair{})
+
+	if !a.Contains(unsafe.Pointer(p.Get())) {
+		t.Error("expected arena to contain its Alloc value")
+	}
+	if !a.Contains(unsafe.Pointer(&p.Get().Y)) {
+		t.Error("expected arena to contain an interior pointer")
+	}
+	if !a.Contains(unsafe.Pointer(&s.Get()[7])) {
+		t.Error("expected arena to contain the last slice element")
+	}
+	if a.Contains(unsafe.Pointer(heap)) || a.Contains(unsafe.Pointer(other.Get())) {
+		t.Error("expected arena not to contain heap or other-arena pointers")
+	}
+	if a.Contains(nil) {
+		t.Error("expected arena not to contain nil")
+	}
+
+	ptr := unsafe.Pointer(p.Get())
+	a.Free()
+	if !a.Contains(ptr) {
+		t.Error("expected Contains to keep answering after Free")
+	}
+}
+
+func TestContainsWithoutDebug(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	if a.Contains(unsafe.Pointer(Alloc(a, 1).Get())) {
+		t.Error("expected Contains to report false outside debug mode")
+	}
+}
@@ -0,0 +1,299 @@
+package safearena
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// derefDeep makes Deref and Clone deep-copy values to the heap
+var derefDeep atomic.Bool
+
+// SetDerefDeep controls whether Deref and Clone deep-copy values.
+//
+// By default Deref and Clone make a shallow copy: slice, map, pointer, Ptr, and
+// Slice fields in the copied value still refer to their original storage, which
+// may live in the arena. With deep mode enabled those fields are recursively
+// copied to the heap (as DeepClone does), so the returned value stays fully
+// usable after the arena is freed.
+//
+// Deep copies use reflection and are much slower than shallow copies. Types
+// without reference fields are detected (and cached per type) and still take
+// the fast shallow path.
+//
+// Example:
+//
+//	safearena.SetDerefDeep(true)
+//	result := safearena.Scoped(func(a *safearena.Arena) Packet {
+//	    p := safearena.Alloc(a, Packet{Payload: safearena.AllocSlice[byte](a, 64)})
+//	    return p.Deref() // Payload is heap-backed, safe after Free
+//	})
+func SetDerefDeep(enabled bool) {
+	derefDeep.Store(enabled)
+}
+
+// heapArena owns Ptr and Slice values produced by deep copies.
+// It is never freed, so those values remain valid indefinitely.
+var heapArena = &Arena{}
+
+// deepCopier is implemented by SafeArena types that hold arena references
+type deepCopier interface {
+	deepCopy(c *copier) any
+}
+
+var deepCopierType = reflect.TypeFor[deepCopier]()
+
+// needsDeepCache caches needsDeepCopy results: reflect.Type -> bool
+var needsDeepCache sync.Map
+
+// needsDeepCopy reports whether values of type t can reference other memory
+// that a shallow copy would share
+func needsDeepCopy(t reflect.Type) bool {
+	if cached, ok := needsDeepCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	needs := false
+	switch {
+	case t.Kind() != reflect.Pointer && t.Implements(deepCopierType):
+		needs = true
+	case t.Kind() == reflect.Slice, t.Kind() == reflect.Map, t.Kind() == reflect.Interface,
+		t.Kind() == reflect.Pointer:
+		needs = true
+	case t.Kind() == reflect.Array:
+		needs = needsDeepCopy(t.Elem())
+	case t.Kind() == reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if needsDeepCopy(t.Field(i).Type) {
+				needs = true
+				break
+			}
+		}
+	}
+
+	needsDeepCache.Store(t, needs)
+	return needs
+}
+
+// DeepClone copies an arena value and everything it references to the heap.
+// Unlike Clone, which copies only the top-level value, DeepClone recursively
+// copies slice, map, pointer, Ptr, and Slice fields (including nested structs
+// and slices of structs), so the result is fully heap-resident and safe to use
+// after the arena is freed. Shared and cyclic references are preserved: each
+// referenced object is copied once.
+//
+// DeepClone uses reflection and is much slower than Clone. Use it when
+// correctness matters more than speed, such as extracting a result that
+// embeds arena buffers.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	pipeline := safearena.Alloc(a, FilterPipeline{Buffer: safearena.AllocSlice[byte](a, size)})
+//	result := safearena.DeepClone(pipeline) // Buffer copied to heap too
+//	a.Free()
+//	use(result.Buffer.Get()) // Safe
+func DeepClone[T any](p Ptr[T]) *T {
+	if p.arena.freed.Load() {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(errorWithSites(p.arena, "DeepClone() called after free", stack, site, CloneAfterFree))
+	}
+
+	src := p.Get() // Panics if reset
+	if !needsDeepCopy(reflect.TypeFor[T]()) {
+		heapCopy := new(T)
+		*heapCopy = *src
+		return heapCopy
+	}
+
+	c := newCopier()
+	return c.copyPointer(reflect.ValueOf(src)).Interface().(*T)
+}
+
+// DerefDeep is like Deref but always returns a fully heap-resident deep copy,
+// whatever SetDerefDeep says: slice, map, pointer, Ptr, and Slice fields are
+// copied recursively, as with DeepClone. Use it to return a value from Scoped
+// when the value's fields may point into the arena:
+//
+//	return safearena.Scoped(func(a *safearena.Arena) Packet {
+//	    p := buildPacket(a) // Payload and Tags are arena-backed
+//	    return p.DerefDeep() // Safe after Scoped frees the arena
+//	})
+//
+// Types without reference fields take the fast shallow path, so DerefDeep
+// costs the same as Deref for them. Otherwise it walks the value with
+// reflection and allocates every referenced object on the heap, which is
+// much slower than Deref.
+//
+// Panics if the arena has been freed or reset.
+func (p Ptr[T]) DerefDeep() T {
+	return deepCopy(*p.Get())
+}
+
+// deepCopy returns a copy of v whose slice, map, pointer, Ptr, and Slice fields
+// are recursively copied to the heap
+func deepCopy[T any](v T) T {
+	if !needsDeepCopy(reflect.TypeFor[T]()) {
+		return v
+	}
+
+	c := newCopier()
+	return c.copy(reflect.ValueOf(&v).Elem()).Interface().(T)
+}
+
+// visitKey identifies a referenced object already copied during a deep copy
+type visitKey struct {
+	addr uintptr
+	typ  reflect.Type
+	len  int // for slices; views of different lengths are distinct
+}
+
+// copier performs a single deep copy, remembering what it has already copied
+// so shared references stay shared and cycles terminate
+type copier struct {
+	seen map[visitKey]reflect.Value
+}
+
+func newCopier() *copier {
+	return &copier{seen: make(map[visitKey]reflect.Value)}
+}
+
+// copyPointer returns a heap pointer to a copy of the value ptr points to,
+// reusing the earlier copy if ptr was already visited
+func (c *copier) copyPointer(ptr reflect.Value) reflect.Value {
+	key := visitKey{addr: ptr.Pointer(), typ: ptr.Type()}
+	if dst, ok := c.seen[key]; ok {
+		return dst
+	}
+
+	dst := reflect.New(ptr.Type().Elem())
+	c.seen[key] = dst // Register before recursing to break cycles
+	dst.Elem().Set(c.copy(ptr.Elem()))
+	return dst
+}
+
+// copy returns an addressable heap copy of v.
+// v must not have been obtained through an unexported field (see unrestricted).
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	t := v.Type()
+	out := reflect.New(t).Elem()
+
+	if !needsDeepCopy(t) {
+		out.Set(v)
+		return out
+	}
+
+	// A *Slice or *Ptr implements deepCopier through its element's methods;
+	// it goes through copyPointer to keep its type and cycle tracking
+	if t.Kind() != reflect.Pointer && t.Implements(deepCopierType) {
+		out.Set(reflect.ValueOf(v.Interface().(deepCopier).deepCopy(c)))
+		return out
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return out
+		}
+		key := visitKey{addr: v.Pointer(), typ: t, len: v.Len()}
+		if dst, ok := c.seen[key]; ok {
+			out.Set(dst)
+			return out
+		}
+		s := reflect.MakeSlice(t, v.Len(), v.Len())
+		c.seen[key] = s
+		if needsDeepCopy(t.Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				s.Index(i).Set(c.copy(v.Index(i)))
+			}
+		} else {
+			reflect.Copy(s, v)
+		}
+		out.Set(s)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return out
+		}
+		key := visitKey{addr: v.Pointer(), typ: t}
+		if dst, ok := c.seen[key]; ok {
+			out.Set(dst)
+			return out
+		}
+		m := reflect.MakeMapWithSize(t, v.Len())
+		c.seen[key] = m
+		iter := v.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		out.Set(m)
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.copy(v.Index(i)))
+		}
+
+	case reflect.Struct:
+		if !v.CanAddr() {
+			tmp := reflect.New(t).Elem()
+			tmp.Set(v)
+			v = tmp
+		}
+		for i := 0; i < t.NumField(); i++ {
+			unrestricted(out.Field(i)).Set(c.copy(unrestricted(v.Field(i))))
+		}
+
+	case reflect.Pointer:
+		if !v.IsNil() {
+			out.Set(c.copyPointer(v))
+		}
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(c.copy(v.Elem()))
+		}
+
+	default:
+		out.Set(v)
+	}
+
+	return out
+}
+
+// unrestricted returns a settable, interfaceable view of an addressable struct
+// field, lifting the read-only restriction reflect places on unexported fields
+func unrestricted(f reflect.Value) reflect.Value {
+	if f.CanSet() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// deepCopy copies the pointed-to value to the heap and returns a Ptr that is
+// owned by heapArena
+func (p Ptr[T]) deepCopy(c *copier) any {
+	if p.arena == nil {
+		return p
+	}
+	dst := c.copyPointer(reflect.ValueOf(p.Get()))
+	return Ptr[T]{
+		ptr:   dst.Interface().(*T),
+		arena: heapArena,
+	}
+}
+
+// deepCopy copies the slice contents to the heap and returns a Slice that is
+// owned by heapArena
+func (s Slice[T]) deepCopy(c *copier) any {
+	if s.arena == nil {
+		return s
+	}
+	val := c.copy(reflect.ValueOf(s.Get()))
+	return Slice[T]{
+		slice: val.Interface().([]T),
+		arena: heapArena,
+	}
+}
@@ -0,0 +1,277 @@
+package safearena
+
+import (
+	"testing"
+)
+
+type packet struct {
+	ID      int
+	Payload Slice[byte]
+	Tags    []string
+	Meta    map[string]int
+}
+
+// enableDerefDeep turns on deep Deref for the duration of a test
+func enableDerefDeep(t *testing.T) {
+	t.Helper()
+	SetDerefDeep(true)
+	t.Cleanup(func() { SetDerefDeep(false) })
+}
+
+func TestDerefDeepSliceField(t *testing.T) {
+	enableDerefDeep(t)
+
+	a := New()
+	payload := AllocSlice[byte](a, 4)
+	copy(payload.Get(), "data")
+	p := Alloc(a, packet{ID: 1, Payload: payload})
+
+	val := p.Deref()
+	a.Free()
+
+	// The Slice field was copied to the heap and survives Free
+	if got := string(val.Payload.Get()); got != "data" {
+		t.Errorf("expected data, got %q", got)
+	}
+	if val.ID != 1 {
+		t.Errorf("expected ID 1, got %d", val.ID)
+	}
+}
+
+func TestDerefShallowByDefault(t *testing.T) {
+	a := New()
+	p := Alloc(a, packet{Payload: AllocSlice[byte](a, 4)})
+
+	val := p.Deref()
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic accessing arena slice after free")
+		}
+	}()
+	_ = val.Payload.Get()
+}
+
+func TestDerefDeepCopiesReferenceFields(t *testing.T) {
+	enableDerefDeep(t)
+
+	result := Scoped(func(a *Arena) packet {
+		p := Alloc(a, packet{
+			Tags: []string{"a", "b"},
+			Meta: map[string]int{"k": 1},
+		})
+		val := p.Deref()
+
+		// Mutating the arena value must not affect the copy
+		p.Get().Tags[0] = "changed"
+		p.Get().Meta["k"] = 2
+		return val
+	})
+
+	if result.Tags[0] != "a" {
+		t.Errorf("expected tag a, got %s", result.Tags[0])
+	}
+	if result.Meta["k"] != 1 {
+		t.Errorf("expected meta 1, got %d", result.Meta["k"])
+	}
+}
+
+func TestCloneDeep(t *testing.T) {
+	enableDerefDeep(t)
+
+	type node struct {
+		Value int
+		next  Ptr[int]
+	}
+
+	a := New()
+	p := Alloc(a, node{Value: 1, next: Alloc(a, 2)})
+	heapCopy := Clone(p)
+	a.Free()
+
+	if heapCopy.Value != 1 {
+		t.Errorf("expected 1, got %d", heapCopy.Value)
+	}
+	if got := heapCopy.next.Deref(); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+}
+
+func TestDerefDeepZeroFields(t *testing.T) {
+	enableDerefDeep(t)
+
+	result := Scoped(func(a *Arena) packet {
+		return Alloc(a, packet{ID: 5}).Deref()
+	})
+
+	if result.ID != 5 {
+		t.Errorf("expected 5, got %d", result.ID)
+	}
+	if result.Tags != nil || result.Meta != nil {
+		t.Error("expected nil reference fields to stay nil")
+	}
+}
+
+func TestDeepCloneNested(t *testing.T) {
+	type inner struct {
+		Label  string
+		Buffer Slice[byte]
+	}
+	type outer struct {
+		Inner  inner
+		Items  []inner
+		Lookup map[string]*inner
+	}
+
+	a := New()
+	buf := func(s string) Slice[byte] {
+		b := AllocSlice[byte](a, len(s))
+		copy(b.Get(), s)
+		return b
+	}
+	shared := &inner{Label: "shared", Buffer: buf("ptr")}
+	p := Alloc(a, outer{
+		Inner:  inner{Label: "nested", Buffer: buf("nested")},
+		Items:  []inner{{Label: "item", Buffer: buf("item")}},
+		Lookup: map[string]*inner{"a": shared, "b": shared},
+	})
+
+	clone := DeepClone(p)
+	a.Free()
+
+	if got := string(clone.Inner.Buffer.Get()); got != "nested" {
+		t.Errorf("expected nested, got %q", got)
+	}
+	if got := string(clone.Items[0].Buffer.Get()); got != "item" {
+		t.Errorf("expected item, got %q", got)
+	}
+	if got := string(clone.Lookup["a"].Buffer.Get()); got != "ptr" {
+		t.Errorf("expected ptr, got %q", got)
+	}
+	if clone.Lookup["a"] == shared {
+		t.Error("expected pointer fields to be copied")
+	}
+	if clone.Lookup["a"] != clone.Lookup["b"] {
+		t.Error("expected shared references to stay shared")
+	}
+}
+
+func TestDeepClonePointerToWrapper(t *testing.T) {
+	type holder struct {
+		Buf   *Slice[byte]
+		Count *Ptr[int]
+		Again *Slice[byte]
+	}
+
+	a := New()
+	s := AllocSlice[byte](a, 3)
+	copy(s.Get(), "abc")
+	n := Alloc(a, 7)
+	p := Alloc(a, holder{Buf: &s, Count: &n, Again: &s})
+
+	clone := DeepClone(p)
+	a.Free()
+
+	if got := string(clone.Buf.Get()); got != "abc" {
+		t.Errorf("expected abc, got %q", got)
+	}
+	if got := clone.Count.Deref(); got != 7 {
+		t.Errorf("expected 7, got %d", got)
+	}
+	if clone.Buf == &s {
+		t.Error("expected the *Slice field to be copied")
+	}
+	if clone.Buf != clone.Again {
+		t.Error("expected shared *Slice references to stay shared")
+	}
+}
+
+func TestDeepCloneCycles(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+		Peer  Ptr[node]
+	}
+
+	t.Run("pointer cycle", func(t *testing.T) {
+		a := New()
+		p := Alloc(a, node{Value: 1})
+		second := &node{Value: 2, Next: p.Get()}
+		p.Get().Next = second
+
+		clone := DeepClone(p)
+		a.Free()
+
+		if clone.Next.Value != 2 || clone.Next.Next != clone {
+			t.Error("expected pointer cycle to be preserved")
+		}
+	})
+
+	t.Run("Ptr cycle", func(t *testing.T) {
+		a := New()
+		p := Alloc(a, node{Value: 1})
+		p.Get().Peer = p // Self-reference
+
+		clone := DeepClone(p)
+		a.Free()
+
+		if clone.Peer.Get() != clone {
+			t.Error("expected Ptr self-reference to point at the clone")
+		}
+	})
+}
+
+func TestDeepCloneAfterFree(t *testing.T) {
+	a := New()
+	p := Alloc(a, packet{ID: 1})
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on deep clone after free")
+		}
+	}()
+	_ = DeepClone(p)
+}
+
+func TestDeepCloneValueType(t *testing.T) {
+	a := New()
+	p := Alloc(a, 42)
+	clone := DeepClone(p)
+	a.Free()
+
+	if *clone != 42 {
+		t.Errorf("expected 42, got %d", *clone)
+	}
+}
+
+func TestPtrDerefDeep(t *testing.T) {
+	type record struct {
+		Name string
+		Body []byte
+	}
+
+	result := Scoped(func(a *Arena) record {
+		body := AllocSlice[byte](a, 5)
+		copy(body.Get(), "hello")
+		return Alloc(a, record{Name: "r", Body: body.Get()}).DerefDeep()
+	})
+	if string(result.Body) != "hello" || result.Name != "r" {
+		t.Errorf("expected heap copy of the arena body, got %+v", result)
+	}
+
+	a := New()
+	defer a.Free()
+	body := AllocSlice[byte](a, 1)
+	p := Alloc(a, record{Body: body.Get()})
+	if &p.Deref().Body[0] != &body.Get()[0] {
+		t.Error("expected Deref to stay shallow without SetDerefDeep")
+	}
+	if &p.DerefDeep().Body[0] == &body.Get()[0] {
+		t.Error("expected DerefDeep to copy the slice field")
+	}
+	if n := Alloc(a, 42).DerefDeep(); n != 42 {
+		t.Errorf("expected 42, got %d", n)
+	}
+}
@@ -0,0 +1,96 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+	"weak"
+)
+
+// Detachment describes a value copied out of a debug-mode arena by Detach
+type Detachment struct {
+	Type  string // Detached type, e.g. "main.Config"
+	Site  string // Detach call site as "file:line (function)", or "unknown"
+	Bytes int    // Size of the heap copy (shallow)
+	Live  bool   // Whether the heap copy was still reachable at the last GC
+}
+
+// detachRecord is the debug-mode record of one Detach call
+type detachRecord struct {
+	typ   reflect.Type
+	site  *stackInfo
+	size  uintptr
+	alive func() bool           // Reports whether the heap copy is still reachable
+	value func() unsafe.Pointer // Returns the heap copy, or nil once collected
+}
+
+// Detach copies the value to the heap, exactly like Clone, while the arena
+// stays alive for further use. Use it to pull one structure out of an arena
+// whose other data is still needed.
+//
+// In debug mode (see Debug) the arena also records each Detach, and
+// Arena.Detached lists them for auditing: many detachments from one site, or
+// copies that are no longer live soon after, point at over-cloning. The
+// records hold only weak references, so they never keep a copy alive.
+//
+// Panics if the arena has been freed or reset.
+//
+// Example:
+//
+//	cfg := safearena.Detach(parsed) // Keep the config; the arena lives on
+//	for _, d := range a.Detached() {
+//	    log.Printf("%s detached at %s (live=%v)", d.Type, d.Site, d.Live)
+//	}
+func Detach[T any](p Ptr[T]) *T {
+	if p.arena.freed.Load() {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(errorWithSites(p.arena, "Detach() called after free", stack, site, CloneAfterFree))
+	}
+
+	heapCopy := new(T)
+	*heapCopy = p.Deref() // Panics if reset
+	if d := p.arena.debug; d != nil {
+		w := weak.Make(heapCopy)
+		rec := &detachRecord{
+			typ:   reflect.TypeFor[T](),
+			site:  captureStack(2),
+			size:  unsafe.Sizeof(*heapCopy),
+			alive: func() bool { return w.Value() != nil },
+			value: func() unsafe.Pointer { return unsafe.Pointer(w.Value()) },
+		}
+		d.mu.Lock()
+		d.detached = append(d.detached, rec)
+		d.mu.Unlock()
+		if d.checkAliases {
+			registerDetached(rec)
+		}
+	}
+	return heapCopy
+}
+
+// Detached returns a record of every Detach from the arena, in call order.
+// Only arenas created while Debug is set keep records; other arenas return
+// nil. Records survive Reset and Free, so they can be audited at the end of
+// a run.
+//
+// Live is best effort: the runtime batches very small pointer-free values
+// (under 16 bytes) into shared blocks, so such a copy can be reported live
+// for as long as its neighbours are.
+func (a *Arena) Detached() []Detachment {
+	if a.debug == nil {
+		return nil
+	}
+
+	a.debug.mu.Lock()
+	defer a.debug.mu.Unlock()
+	out := make([]Detachment, len(a.debug.detached))
+	for i, rec := range a.debug.detached {
+		out[i] = Detachment{
+			Type:  rec.typ.String(),
+			Site:  siteString(rec.site),
+			Bytes: int(rec.size),
+			Live:  rec.alive(),
+		}
+	}
+	return out
+}
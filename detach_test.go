@@ -0,0 +1,73 @@
+package safearena
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDetach(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	type config struct{ Port int }
+	p := Alloc(a, config{Port: 8080})
+	cfg := Detach(p)
+	cfg.Port = 9090
+
+	if p.Get().Port != 8080 {
+		t.Error("expected the arena value to be unaffected by changes to the copy")
+	}
+	if !p.Valid() || a.IsFreed() {
+		t.Error("expected the arena to stay alive after Detach")
+	}
+	if a.Detached() != nil {
+		t.Error("expected no records outside debug mode")
+	}
+}
+
+func TestDetachedAudit(t *testing.T) {
+	enableDebug(t)
+	a := New()
+	defer a.Free()
+
+	// Large enough to avoid the tiny allocator, which batches small objects
+	type block struct{ Data [64]byte }
+	kept := Detach(Alloc(a, int64(1)))
+	for range 3 {
+		_ = Detach(Alloc(a, block{})) // Dropped immediately: over-cloning
+	}
+	runtime.GC()
+
+	records := a.Detached()
+	if len(records) != 4 {
+		t.Fatalf("expected 4 detachments, got %d", len(records))
+	}
+	if r := records[0]; r.Type != "int64" || r.Bytes != 8 || !r.Live {
+		t.Errorf("expected live int64 record, got %+v", r)
+	}
+	if !strings.Contains(records[0].Site, "detach_test.go") {
+		t.Errorf("expected Detach call site, got %q", records[0].Site)
+	}
+	for _, r := range records[1:] {
+		if r.Type != "safearena.block" || r.Bytes != 64 || r.Live {
+			t.Errorf("expected collected block record, got %+v", r)
+		}
+	}
+	runtime.KeepAlive(kept)
+}
+
+func TestDetachAfterFree(t *testing.T) {
+	a := New()
+	p := Alloc(a, 1)
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "Detach() called after free") {
+			t.Errorf("expected Detach after free panic, got %q", msg)
+		}
+	}()
+	Detach(p)
+}
@@ -41,10 +41,20 @@
 //
 // All panics include stack traces and hints for fixing the issue.
 //
+// # Concurrency
+//
+// Reading arena values from several goroutines is safe: Get and Deref only
+// load the arena's state atomically. Allocation from one Arena is not; use
+// SharedArena, or one arena per goroutine. Free must not race with readers;
+// when program structure cannot rule that out, create the arena with
+// NewConcurrent and have readers hold AcquireRead, which makes Free wait for
+// them.
+//
 // # Performance
 //
-// SafeArena adds minimal overhead (single atomic load per access) while providing
-// strong safety guarantees:
+// SafeArena adds minimal overhead (two atomic loads per access: the freed flag
+// and the generation that Reset bumps) while providing strong safety
+// guarantees:
 //
 //	BenchmarkSafeArena    104.8 μs/op    406 KB/op    0.047ms GC pause
 //	BenchmarkRegularGC     92.5 μs/op    256 KB/op    0.082ms GC pause
@@ -78,7 +88,16 @@
 //
 // # Requirements
 //
-// Requires Go 1.23+ with GOEXPERIMENT=arenas environment variable set.
+// Requires Go 1.23+. Set GOEXPERIMENT=arenas to allocate from Go arenas.
+//
+// Without the experiment the package still builds, falling back to the
+// garbage-collected heap: Alloc and friends use new and make, and Free
+// releases nothing itself. Every lifetime check is part of Arena rather than
+// the memory source, so use after free, double free, Reset, Mark, budgets,
+// and the other safety semantics behave identically; only the GC savings are
+// lost. Libraries can therefore depend on safearena unconditionally and get
+// the performance benefit wherever arenas are enabled. ArenasEnabled reports
+// which mode was built.
 //
 // The arena package is currently experimental. Use for research and development,
 // not production systems.
@@ -0,0 +1,85 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Equal reports whether the pointed-to value equals other, using ==.
+// It is meant for terse assertions in tests of arena-heavy code.
+//
+// Panics if the arena has been freed or reset, or if T is not comparable
+// (for example a struct containing a slice); use DeepEqual for those.
+//
+// Example:
+//
+//	p := safearena.Alloc(a, Point{X: 1, Y: 2})
+//	if !p.Equal(Point{X: 1, Y: 2}) {
+//	    t.Errorf("got %+v", p.Deref())
+//	}
+func (p Ptr[T]) Equal(other T) bool {
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(p.arena.staleError(p.gen, stack, site))
+	}
+	return any(*p.ptr) == any(other)
+}
+
+// DeepEqual reports whether the pointed-to value is deeply equal to other,
+// as defined by reflect.DeepEqual. Unlike Equal it works for any T.
+//
+// Panics if the arena has been freed or reset.
+func (p Ptr[T]) DeepEqual(other T) bool {
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(p.arena.staleError(p.gen, stack, site))
+	}
+	return reflect.DeepEqual(*p.ptr, other)
+}
+
+// Equal reports whether the slice has the same length as other and equal
+// elements, compared with ==.
+//
+// Panics if the arena has been freed or reset, or if T is not comparable;
+// use DeepEqual for those.
+//
+// Example:
+//
+//	s := safearena.AllocSlice[int](a, 3)
+//	copy(s.Get(), []int{1, 2, 3})
+//	if !s.Equal([]int{1, 2, 3}) { ... }
+func (s Slice[T]) Equal(other []T) bool {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if len(s.slice) != len(other) {
+		return false
+	}
+	for i := range s.slice {
+		if any(s.slice[i]) != any(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeepEqual reports whether the slice is deeply equal to other, as defined by
+// reflect.DeepEqual. A zero-length slice is deeply equal to any other
+// zero-length slice, including nil.
+//
+// Panics if the arena has been freed or reset.
+func (s Slice[T]) DeepEqual(other []T) bool {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if len(s.slice) == 0 && len(other) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(s.slice, other)
+}
@@ -0,0 +1,91 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPtrEqual(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	type point struct{ X, Y int }
+	p := Alloc(a, point{X: 1, Y: 2})
+	if !p.Equal(point{X: 1, Y: 2}) {
+		t.Error("expected equal values")
+	}
+	if p.Equal(point{X: 2, Y: 1}) {
+		t.Error("expected different values")
+	}
+
+	type record struct {
+		Name string
+		Tags []string
+	}
+	r := Alloc(a, record{Name: "x", Tags: []string{"a", "b"}})
+	if !r.DeepEqual(record{Name: "x", Tags: []string{"a", "b"}}) {
+		t.Error("expected deeply equal values")
+	}
+	if r.DeepEqual(record{Name: "x", Tags: []string{"a"}}) {
+		t.Error("expected deeply different values")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic comparing a non-comparable type with Equal")
+		}
+	}()
+	r.Equal(record{})
+}
+
+func TestSliceEqual(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := AllocSlice[int](a, 3)
+	copy(s.Get(), []int{1, 2, 3})
+	if !s.Equal([]int{1, 2, 3}) {
+		t.Error("expected equal slices")
+	}
+	if s.Equal([]int{1, 2}) || s.Equal([]int{1, 2, 4}) {
+		t.Error("expected different slices")
+	}
+
+	nested := AllocSlice[[]byte](a, 1)
+	nested.Get()[0] = []byte("x")
+	if !nested.DeepEqual([][]byte{[]byte("x")}) {
+		t.Error("expected deeply equal slices")
+	}
+	if !AllocSlice[int](a, 0).DeepEqual(nil) {
+		t.Error("expected empty slice to deeply equal nil")
+	}
+}
+
+func TestEqualAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(p Ptr[int], s Slice[int])
+	}{
+		{"ptr equal", func(p Ptr[int], _ Slice[int]) { p.Equal(1) }},
+		{"ptr deep equal", func(p Ptr[int], _ Slice[int]) { p.DeepEqual(1) }},
+		{"slice equal", func(_ Ptr[int], s Slice[int]) { s.Equal(nil) }},
+		{"slice deep equal", func(_ Ptr[int], s Slice[int]) { s.DeepEqual(nil) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			p, s := Alloc(a, 1), AllocSlice[int](a, 1)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use(p, s)
+		})
+	}
+}
@@ -4,67 +4,307 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync/atomic"
 )
 
-// stackInfo captures a stack trace for debugging
+// maxStackFrames bounds how many frames captureStack records
+const maxStackFrames = 8
+
+// Frame is a single source location in an ArenaError stack
+type Frame struct {
+	File     string // Base file name
+	Line     int
+	Function string // Package-qualified function name
+}
+
+// stackInfo captures a short stack trace for debugging.
+// The embedded frame is the call site; callers are the frames above it.
 type stackInfo struct {
-	file string
-	line int
-	fn   string
+	Frame
+	callers []Frame // Innermost first
+}
+
+// frames returns the call site followed by its callers, or nil
+func (s *stackInfo) frames() []Frame {
+	if s == nil {
+		return nil
+	}
+	return append([]Frame{s.Frame}, s.callers...)
+}
+
+// stackCaptureOff disables stack capture in error messages
+var stackCaptureOff atomic.Bool
+
+// SetStackCapture controls whether safety violation messages include a short
+// stack trace of the offending call. Capture is enabled by default.
+//
+// Capturing the location walks up to eight stack frames per violation. That
+// is negligible when violations are fatal bugs, but adds up when violations
+// are expected and recovered (for example, logged by middleware and ignored).
+// Disable capture to keep that path cheap: BenchmarkUseAfterFreeLogged shows
+// capture accounts for most of the cost of a recovered violation
+// (~10μs with capture vs ~1.9μs without on amd64).
+func SetStackCapture(enabled bool) {
+	stackCaptureOff.Store(!enabled)
 }
 
-// captureStack captures the current stack location (2 frames up)
+// captureStack captures up to maxStackFrames of the current stack, starting
+// skip frames up (as with runtime.Caller, skip 0 is captureStack itself)
 func captureStack(skip int) *stackInfo {
-	pc, file, line, ok := runtime.Caller(skip)
-	if !ok {
+	if stackCaptureOff.Load() {
+		return nil
+	}
+
+	var pcs [maxStackFrames]uintptr
+	n := runtime.Callers(skip+1, pcs[:])
+	if n == 0 {
 		return nil
 	}
 
-	fn := runtime.FuncForPC(pc)
-	fnName := "unknown"
-	if fn != nil {
-		fnName = fn.Name()
-		// Simplify function name
-		if idx := strings.LastIndex(fnName, "/"); idx >= 0 {
-			fnName = fnName[idx+1:]
+	info := &stackInfo{}
+	frames := runtime.CallersFrames(pcs[:n])
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if i > 0 && strings.HasPrefix(frame.Function, "runtime.") {
+			break // Goroutine entry and panic machinery are noise
+		}
+
+		sf := simplifyFrame(frame)
+		if i == 0 {
+			info.Frame = sf
+		} else {
+			info.callers = append(info.callers, sf)
+		}
+		if !more {
+			break
 		}
 	}
+	return info
+}
+
+// simplifyFrame trims a runtime frame to base file and package-qualified
+// function names
+func simplifyFrame(frame runtime.Frame) Frame {
+	fn := frame.Function
+	if fn == "" {
+		fn = "unknown"
+	}
+	if idx := strings.LastIndex(fn, "/"); idx >= 0 {
+		fn = fn[idx+1:]
+	}
 
-	// Simplify file path
+	file := frame.File
 	if idx := strings.LastIndex(file, "/"); idx >= 0 {
 		file = file[idx+1:]
 	}
 
-	return &stackInfo{
-		file: file,
-		line: line,
-		fn:   fnName,
+	return Frame{File: file, Line: frame.Line, Function: fn}
+}
+
+// writeTrace writes a call site and its callers as an indented trace
+func writeTrace(msg *strings.Builder, label string, frames []Frame) {
+	fmt.Fprintf(msg, "\n  %s %s:%d (%s)", label, frames[0].File, frames[0].Line, frames[0].Function)
+	for _, c := range frames[1:] {
+		fmt.Fprintf(msg, "\n      called from %s:%d (%s)", c.File, c.Line, c.Function)
+	}
+}
+
+// ErrorKind classifies an ArenaError
+type ErrorKind int
+
+const (
+	// UseAfterFree is an access to a value after its arena was freed
+	UseAfterFree ErrorKind = iota
+	// DoubleFree is a second Free of the same arena
+	DoubleFree
+	// AllocAfterFree is an allocation (or other arena operation) after Free
+	AllocAfterFree
+	// UseAfterReset is an access to a value allocated before a Reset
+	UseAfterReset
+	// UseAfterRelease is an access to a value allocated after a released Mark
+	UseAfterRelease
+	// CloneAfterFree is a Clone of a value after its arena was freed
+	CloneAfterFree
+	// BudgetExceeded is an allocation past the NewWithLimit budget
+	BudgetExceeded
+	// WriteToFrozen is a write to a value frozen with Ptr.Freeze or Slice.Freeze
+	WriteToFrozen
+	// AllocInFrozen is an allocation from an arena frozen with Arena.Freeze
+	AllocInFrozen
+	// UseAfterTake is an access to a Slice whose contents TakeBytes moved to the heap
+	UseAfterTake
+	// FreeWhileAliased is a Free of an arena that a value copied out by Detach
+	// still points into (see CheckAliases)
+	FreeWhileAliased
+)
+
+// kindNames holds ErrorKind names for String
+var kindNames = [...]string{
+	UseAfterFree:     "UseAfterFree",
+	DoubleFree:       "DoubleFree",
+	AllocAfterFree:   "AllocAfterFree",
+	UseAfterReset:    "UseAfterReset",
+	UseAfterRelease:  "UseAfterRelease",
+	CloneAfterFree:   "CloneAfterFree",
+	BudgetExceeded:   "BudgetExceeded",
+	WriteToFrozen:    "WriteToFrozen",
+	AllocInFrozen:    "AllocInFrozen",
+	UseAfterTake:     "UseAfterTake",
+	FreeWhileAliased: "FreeWhileAliased",
+}
+
+// String returns the kind's name
+func (k ErrorKind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
 	}
+	return kindNames[k]
 }
 
-// errorWithHint creates a panic message with helpful hints
-func errorWithHint(arenaID uint64, errorType string, stack *stackInfo, hint string) string {
+// ArenaError describes a safety violation. SafeArena panics with an
+// *ArenaError, so recovering code can react to specific kinds of violation
+// instead of matching message text.
+//
+// Example:
+//
+//	defer func() {
+//	    if err, ok := recover().(*safearena.ArenaError); ok && err.Kind == safearena.UseAfterFree {
+//	        log.Printf("arena %d: stale access: %v", err.ArenaID, err)
+//	        http.Error(w, "internal error", http.StatusInternalServerError)
+//	    }
+//	}()
+type ArenaError struct {
+	ArenaID   uint64
+	ArenaName string // Label from NewNamed; empty for unnamed arenas
+	Kind      ErrorKind
+	Stack     []Frame // Offending call and its callers; nil when stack capture is off
+
+	// AllocStack is where the accessed value was allocated, for arenas
+	// created in debug mode; otherwise nil
+	AllocStack []Frame
+
+	what string // Short description, such as "Clone() called after free"
+}
+
+// Error returns the panic message: the violation, where it happened, and a
+// hint on how to fix it.
+func (e *ArenaError) Error() string {
 	var msg strings.Builder
 
 	// Main error
-	fmt.Fprintf(&msg, "arena %d: %s", arenaID, errorType)
+	fmt.Fprintf(&msg, "%s: %s", arenaLabel(e.ArenaID, e.ArenaName), e.what)
 
 	// Location
-	if stack != nil {
-		fmt.Fprintf(&msg, "\n  at %s:%d (%s)", stack.file, stack.line, stack.fn)
+	if e.AllocStack != nil {
+		if e.Stack != nil {
+			writeTrace(&msg, "accessed at", e.Stack)
+		}
+		writeTrace(&msg, "allocated at", e.AllocStack)
+	} else if e.Stack != nil {
+		writeTrace(&msg, "at", e.Stack)
 	}
 
 	// Hint
-	if hint != "" {
+	if hint := kindHint(e.Kind); hint != "" {
 		fmt.Fprintf(&msg, "\n\n  💡 Hint: %s", hint)
 	}
 
 	return msg.String()
 }
 
-// Common hints
-const (
-	hintUseAfterFree   = "Arena was freed before this access. Use Clone() to copy values to heap, or ensure arena lifetime covers all uses."
-	hintDoubleFree     = "Arena.Free() was called twice. Make sure Free() is only called once, typically with defer."
-	hintAllocAfterFree = "Cannot allocate in a freed arena. Create a new arena or ensure this code runs before Free()."
-)
+// arenaLabel identifies an arena in messages: "arena 7", or with a name,
+// "arena 7 (request-handler)"
+func arenaLabel(id uint64, name string) string {
+	if name == "" {
+		return fmt.Sprintf("arena %d", id)
+	}
+	return fmt.Sprintf("arena %d (%s)", id, name)
+}
+
+// errorWithHint creates the panic value for a violation of the given kind;
+// its message carries a hint on how to fix it
+func errorWithHint(a *Arena, what string, stack *stackInfo, kind ErrorKind) *ArenaError {
+	return errorWithSites(a, what, stack, nil, kind)
+}
+
+// errorWithSites is like errorWithHint but also reports where the accessed
+// value was allocated, when known (debug mode)
+func errorWithSites(a *Arena, what string, stack, allocSite *stackInfo, kind ErrorKind) *ArenaError {
+	return &ArenaError{
+		ArenaID:    a.id,
+		ArenaName:  a.name,
+		Kind:       kind,
+		Stack:      stack.frames(),
+		AllocStack: allocSite.frames(),
+		what:       what,
+	}
+}
+
+// staleError creates the panic message for an access through a Ptr or Slice
+// of generation gen that is no longer valid: the arena was freed, reset since
+// the allocation, released back to a Mark taken before it, or (for a Slice)
+// taken with TakeBytes
+func (a *Arena) staleError(gen uint64, stack, allocSite *stackInfo) *ArenaError {
+	if a.freed.Load() {
+		return errorWithSites(a, "use after free", stack, allocSite, UseAfterFree)
+	}
+	if gen < a.floor.Load() {
+		return errorWithSites(a, "use after reset", stack, allocSite, UseAfterReset)
+	}
+	if !a.liveSlow(gen) {
+		return errorWithSites(a, "use after release", stack, allocSite, UseAfterRelease)
+	}
+	return errorWithSites(a, "use after TakeBytes", stack, allocSite, UseAfterTake)
+}
+
+// kindHints holds the hint shown in each kind's message
+var kindHints = [...]string{
+	UseAfterFree:     "Arena was freed before this access. Use Clone() to copy values to heap, or ensure arena lifetime covers all uses.",
+	DoubleFree:       "Arena.Free() was called twice. Make sure Free() is only called once, typically with defer.",
+	AllocAfterFree:   "Cannot allocate in a freed arena. Create a new arena or ensure this code runs before Free().",
+	UseAfterReset:    "Arena was reset after this value was allocated. Values do not survive Reset(); use Clone() to copy them to heap first.",
+	UseAfterRelease:  "This value was allocated after a Mark() that has since been released. Clone() it before Release(), or take the mark later.",
+	CloneAfterFree:   "Cloning must happen while the arena is alive. Move the Clone() call before Free(), and check the order of deferred calls.",
+	BudgetExceeded:   "Allocation would exceed the byte budget set with NewWithLimit(). Reduce the input size or raise the limit.",
+	WriteToFrozen:    "This value was frozen with Freeze() and is read-only. Clone() it to get a mutable copy.",
+	AllocInFrozen:    "Arena.Freeze() was called, so the arena is read-only. Finish building before Freeze(), or allocate from another arena.",
+	UseAfterTake:     "TakeBytes() moved this slice's contents to the heap. Use the []byte it returned instead of the arena Slice.",
+	FreeWhileAliased: "A detached copy still references memory in this arena. Check that the right arena is being freed, or copy the referenced fields to the heap before Free().",
+}
+
+// hintOverrides holds the hints set with SetHint, indexed by kind
+var hintOverrides [len(kindHints)]atomic.Pointer[string]
+
+// SetHint replaces the hint shown in the message of errors of the given kind,
+// so an organisation can point developers at its own documentation:
+//
+//	safearena.SetHint(safearena.UseAfterFree,
+//	    "See wiki/arenas#lifetimes for how we scope request arenas.")
+//
+// An empty hint restores the default. SetHint is safe for concurrent use and
+// affects every ArenaError message formatted afterwards, including ones
+// created earlier.
+//
+// Panics if kind is not a known ErrorKind.
+func SetHint(kind ErrorKind, hint string) {
+	if kind < 0 || int(kind) >= len(hintOverrides) {
+		panic(fmt.Sprintf("safearena: SetHint with unknown ErrorKind %d", kind))
+	}
+	if hint == "" {
+		hintOverrides[kind].Store(nil)
+		return
+	}
+	hintOverrides[kind].Store(&hint)
+}
+
+// kindHint returns the hint for kind: the one set with SetHint, if any,
+// otherwise the default
+func kindHint(kind ErrorKind) string {
+	if kind < 0 || int(kind) >= len(kindHints) {
+		return ""
+	}
+	if hint := hintOverrides[kind].Load(); hint != nil {
+		return *hint
+	}
+	return kindHints[kind]
+}
@@ -1,6 +1,7 @@
 package safearena
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -13,7 +14,7 @@ func TestImprovedErrorMessages(t *testing.T) {
 				t.Fatal("expected panic")
 			}
 
-			msg := r.(string)
+			msg := r.(*ArenaError).Error()
 			if !strings.Contains(msg, "use after free") {
 				t.Errorf("expected 'use after free', got: %s", msg)
 			}
@@ -40,7 +41,7 @@ func TestImprovedErrorMessages(t *testing.T) {
 				t.Fatal("expected panic")
 			}
 
-			msg := r.(string)
+			msg := r.(*ArenaError).Error()
 			if !strings.Contains(msg, "double free") {
 				t.Errorf("expected 'double free', got: %s", msg)
 			}
@@ -63,7 +64,7 @@ func TestImprovedErrorMessages(t *testing.T) {
 				t.Fatal("expected panic")
 			}
 
-			msg := r.(string)
+			msg := r.(*ArenaError).Error()
 			if !strings.Contains(msg, "allocation after free") {
 				t.Errorf("expected 'allocation after free', got: %s", msg)
 			}
@@ -79,3 +80,296 @@ func TestImprovedErrorMessages(t *testing.T) {
 		_ = Alloc(a, 42) // Should panic with helpful message
 	})
 }
+
+func TestCloneAfterFreeMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		clone func(a *Arena) func()
+		want  string
+	}{
+		{"Clone", func(a *Arena) func() {
+			p := Alloc(a, 42)
+			return func() { Clone(p) }
+		}, "Clone() called after free"},
+		{"CloneSlice", func(a *Arena) func() {
+			s := AllocSlice[int](a, 4)
+			return func() { CloneSlice(s) }
+		}, "CloneSlice() called after free"},
+		{"DeepClone", func(a *Arena) func() {
+			p := Alloc(a, 42)
+			return func() { DeepClone(p) }
+		}, "DeepClone() called after free"},
+		{"CloneInto", func(a *Arena) func() {
+			p := Alloc(a, 42)
+			return func() { CloneInto(new(int), p) }
+		}, "CloneInto() called after free"},
+		{"CloneSliceInto", func(a *Arena) func() {
+			s := AllocSlice[int](a, 4)
+			return func() { CloneSliceInto(make([]int, 4), s) }
+		}, "CloneSliceInto() called after free"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			clone := tt.clone(a)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tt.want) {
+					t.Errorf("expected %q, got: %s", tt.want, msg)
+				}
+				if !strings.Contains(msg, "before Free()") {
+					t.Errorf("expected hint to move the clone before Free(), got: %s", msg)
+				}
+				if !strings.Contains(msg, "errors_test.go") {
+					t.Errorf("expected caller location, got: %s", msg)
+				}
+			}()
+			clone()
+		})
+	}
+}
+
+func TestCloneAfterFreeAllocationSite(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	p := Alloc(a, 42)
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "allocated at errors_test.go") {
+			t.Errorf("expected allocation site, got: %s", msg)
+		}
+	}()
+	Clone(p)
+}
+
+func TestSetStackCapture(t *testing.T) {
+	useAfterFreeMessage := func() (msg string) {
+		defer func() {
+			msg = fmt.Sprint(recover())
+		}()
+		a := New()
+		p := Alloc(a, 42)
+		a.Free()
+		_ = p.Get()
+		return ""
+	}
+
+	if msg := useAfterFreeMessage(); !strings.Contains(msg, "\n  at ") {
+		t.Errorf("expected location in message, got: %s", msg)
+	}
+
+	SetStackCapture(false)
+	defer SetStackCapture(true)
+
+	msg := useAfterFreeMessage()
+	if strings.Contains(msg, "\n  at ") {
+		t.Errorf("expected no location with stack capture disabled, got: %s", msg)
+	}
+	if !strings.Contains(msg, "use after free") || !strings.Contains(msg, "Hint:") {
+		t.Errorf("expected error and hint to remain, got: %s", msg)
+	}
+}
+
+// BenchmarkUseAfterFreeLogged measures a recovered-and-logged violation,
+// the path taken when violations are expected rather than fatal.
+func BenchmarkUseAfterFreeLogged(b *testing.B) {
+	a := New()
+	p := Alloc(a, 42)
+	a.Free()
+
+	logged := func() (msg string) {
+		defer func() {
+			msg = fmt.Sprint(recover())
+		}()
+		_ = p.Get()
+		return ""
+	}
+
+	b.Run("stack capture", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = logged()
+		}
+	})
+
+	b.Run("no stack capture", func(b *testing.B) {
+		SetStackCapture(false)
+		defer SetStackCapture(true)
+		for i := 0; i < b.N; i++ {
+			_ = logged()
+		}
+	})
+}
+
+// readHelper is a shared helper whose callers only show up in a
+// multi-frame trace
+func readHelper(p Ptr[int]) int {
+	return *p.Get()
+}
+
+func callerOne(p Ptr[int]) int { return readHelper(p) }
+
+func TestStackTraceIncludesCallers(t *testing.T) {
+	a := New()
+	p := Alloc(a, 42)
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "\n  at errors_test.go") || !strings.Contains(msg, "(safearena.readHelper)") {
+			t.Errorf("expected first frame at the Get call in readHelper, got: %s", msg)
+		}
+		if !strings.Contains(msg, "\n      called from errors_test.go") || !strings.Contains(msg, "(safearena.callerOne)") {
+			t.Errorf("expected callers in trace, got: %s", msg)
+		}
+		if strings.Contains(msg, "safearena.Ptr") {
+			t.Errorf("expected no internal frames, got: %s", msg)
+		}
+	}()
+	callerOne(p)
+}
+
+func TestArenaErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		want ErrorKind
+	}{
+		{"use after free", func() {
+			a := New()
+			p := Alloc(a, 1)
+			a.Free()
+			p.Get()
+		}, UseAfterFree},
+		{"double free", func() {
+			a := New()
+			a.Free()
+			a.Free()
+		}, DoubleFree},
+		{"allocation after free", func() {
+			a := New()
+			a.Free()
+			Alloc(a, 1)
+		}, AllocAfterFree},
+		{"use after reset", func() {
+			a := New()
+			defer a.Free()
+			p := Alloc(a, 1)
+			a.Reset()
+			p.Get()
+		}, UseAfterReset},
+		{"Clone() called after free", func() {
+			a := New()
+			p := Alloc(a, 1)
+			a.Free()
+			Clone(p)
+		}, CloneAfterFree},
+		{"budget exceeded", func() {
+			a := NewWithLimit(1)
+			defer a.Free()
+			Alloc(a, int64(1))
+		}, BudgetExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(*ArenaError)
+				if !ok {
+					t.Fatal("expected *ArenaError panic")
+				}
+				if err.Kind != tt.want {
+					t.Errorf("expected kind %v, got %v", tt.want, err.Kind)
+				}
+				if !strings.Contains(err.Error(), tt.name) {
+					t.Errorf("expected message to contain %q, got: %s", tt.name, err)
+				}
+				if len(err.Stack) == 0 || err.Stack[0].File != "errors_test.go" {
+					t.Errorf("expected stack to start in errors_test.go, got %+v", err.Stack)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestArenaErrorMessage(t *testing.T) {
+	err := &ArenaError{
+		ArenaID: 7,
+		Kind:    DoubleFree,
+		Stack:   []Frame{{File: "main.go", Line: 10, Function: "main.run"}, {File: "main.go", Line: 3, Function: "main.main"}},
+		what:    "double free",
+	}
+
+	want := "arena 7: double free" +
+		"\n  at main.go:10 (main.run)" +
+		"\n      called from main.go:3 (main.main)" +
+		"\n\n  💡 Hint: " + kindHints[DoubleFree]
+	if got := err.Error(); got != want {
+		t.Errorf("unexpected message:\n%s\nwant:\n%s", got, want)
+	}
+	if got := ErrorKind(99).String(); got != "ErrorKind(99)" {
+		t.Errorf("expected ErrorKind(99), got %s", got)
+	}
+}
+
+func TestSetHint(t *testing.T) {
+	const custom = "See wiki/arenas for our patterns."
+	SetHint(UseAfterFree, custom)
+	t.Cleanup(func() { SetHint(UseAfterFree, "") })
+
+	a := New()
+	p := Alloc(a, 1)
+	a.Free()
+
+	func() {
+		defer func() {
+			msg := fmt.Sprint(recover())
+			if !strings.Contains(msg, "💡 Hint: "+custom) || strings.Contains(msg, kindHints[UseAfterFree]) {
+				t.Errorf("expected the custom hint in place of the default, got %q", msg)
+			}
+		}()
+		p.Get()
+	}()
+
+	SetHint(UseAfterFree, "")
+	err := &ArenaError{Kind: UseAfterFree, what: "use after free"}
+	if !strings.Contains(err.Error(), kindHints[UseAfterFree]) {
+		t.Errorf("expected an empty hint to restore the default, got %q", err.Error())
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "unknown ErrorKind 99") {
+			t.Errorf("expected unknown kind panic, got %q", msg)
+		}
+	}()
+	SetHint(ErrorKind(99), custom)
+}
+
+func TestNamedArenaMessage(t *testing.T) {
+	a := NewNamed("request-handler")
+	p := Alloc(a, 1)
+	a.Free()
+
+	if a.Name() != "request-handler" {
+		t.Errorf("expected name request-handler, got %q", a.Name())
+	}
+
+	defer func() {
+		err, _ := recover().(*ArenaError)
+		if err == nil || err.ArenaName != "request-handler" {
+			t.Fatalf("expected ArenaError with name, got %v", err)
+		}
+		want := fmt.Sprintf("arena %d (request-handler): use after free", err.ArenaID)
+		if !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("expected message to start with %q, got: %s", want, err)
+		}
+	}()
+	p.Get()
+}
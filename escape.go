@@ -0,0 +1,52 @@
+package safearena
+
+import (
+	"runtime"
+	"weak"
+)
+
+// TestingT is the subset of testing.TB used by AssertNoEscape
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertNoEscape runs fn with a fresh arena, frees the arena, and fails the
+// test if anything still references it afterward: a Ptr, Slice, container
+// (NewWriter, NewMap, ...), or the *Arena itself kept in a cache, global, or
+// struct outliving fn. Such references are bugs waiting to happen: the
+// retained values panic as soon as they are used.
+//
+// Detection is exact for those types because it checks, after a garbage
+// collection, whether the arena is still reachable. It cannot see raw *T or
+// []T values obtained from Get; run the test with Debug set so Free poisons
+// that memory instead.
+//
+// AssertNoEscape forces garbage collections, so use it in tests only.
+//
+// Example:
+//
+//	func TestCacheDoesNotRetain(t *testing.T) {
+//	    safearena.AssertNoEscape(t, func(a *safearena.Arena) {
+//	        cache.Process(a, input)
+//	    })
+//	}
+func AssertNoEscape(t TestingT, fn func(*Arena)) {
+	t.Helper()
+
+	ref := runScoped(fn)
+	runtime.GC()
+	if a := ref.Value(); a != nil {
+		t.Errorf("safearena: %s is still referenced after Free; a Ptr, Slice, or the arena escaped fn",
+			arenaLabel(a.id, a.name))
+	}
+}
+
+// runScoped runs fn with a fresh arena, frees it, and returns a weak
+// reference to it, so the caller holds no strong reference of its own
+func runScoped(fn func(*Arena)) weak.Pointer[Arena] {
+	a := New()
+	defer a.Free()
+	fn(a)
+	return weak.Make(a)
+}
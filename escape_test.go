@@ -0,0 +1,55 @@
+package safearena
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// recordingT captures AssertNoEscape failures instead of failing the test
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertNoEscape(t *testing.T) {
+	rt := &recordingT{}
+	AssertNoEscape(rt, func(a *Arena) {
+		p := Alloc(a, 42)
+		_ = CloneSlice(AllocSlice[int](a, 4))
+		_ = p.Deref()
+	})
+
+	if len(rt.errors) != 0 {
+		t.Errorf("expected no escape, got %v", rt.errors)
+	}
+}
+
+func TestAssertNoEscapeDetectsRetainedPtr(t *testing.T) {
+	tests := map[string]func(a *Arena) any{
+		"Ptr":   func(a *Arena) any { return Alloc(a, 42) },
+		"Slice": func(a *Arena) any { return AllocSlice[byte](a, 8) },
+		"Arena": func(a *Arena) any { return a },
+	}
+
+	for name, leak := range tests {
+		t.Run(name, func(t *testing.T) {
+			var retained any
+			rt := &recordingT{}
+			AssertNoEscape(rt, func(a *Arena) {
+				retained = leak(a)
+			})
+			runtime.KeepAlive(retained)
+
+			if len(rt.errors) != 1 || !strings.Contains(rt.errors[0], "still referenced after Free") {
+				t.Errorf("expected escape to be reported, got %v", rt.errors)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package safearena_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/scttfrdmn/safearena"
 )
@@ -65,6 +66,36 @@ func ExampleScoped() {
 	// Output: 4950
 }
 
+func ExampleScopedResult() {
+	type word struct {
+		Text  string
+		Count int
+	}
+
+	var words safearena.PtrSlice[word]
+	longest := safearena.ScopedResult(
+		func(a *safearena.Arena) {
+			// Arena work: build the data
+			for _, w := range strings.Fields("the quick brown fox jumps") {
+				words.Append(a, word{Text: w, Count: len(w)})
+			}
+		},
+		func() string {
+			// Extraction: produce a heap result before the arena is freed
+			best := words.At(0).Get()
+			for i := 1; i < words.Len(); i++ {
+				if w := words.At(i).Get(); w.Count > best.Count {
+					best = w
+				}
+			}
+			return best.Text
+		},
+	)
+
+	fmt.Println(longest)
+	// Output: quick
+}
+
 // ExampleClone shows how to safely copy arena data to the heap.
 func ExampleClone() {
 	a := safearena.New()
@@ -219,3 +250,39 @@ func Example_safetyCheck() {
 
 	// Output: Caught panic: use after free detected
 }
+
+// ExamplePtr_Equal shows terse assertions on arena values.
+func ExamplePtr_Equal() {
+	a := safearena.New()
+	defer a.Free()
+
+	type Point struct{ X, Y int }
+	p := safearena.Alloc(a, Point{X: 1, Y: 2})
+	fmt.Println(p.Equal(Point{X: 1, Y: 2}))
+
+	s := safearena.AllocSlice[string](a, 2)
+	copy(s.Get(), []string{"a", "b"})
+	fmt.Println(s.Equal([]string{"a", "b"}), s.Equal([]string{"a"}))
+	// Output:
+	// true
+	// true false
+}
+
+// ExampleStats_Sub asserts the exact allocation behavior of a function, as a
+// test guarding against performance regressions would.
+func ExampleStats_Sub() {
+	a := safearena.New()
+	defer a.Free()
+
+	buildPair := func(a *safearena.Arena) {
+		safearena.Alloc(a, int64(1))
+		safearena.AllocSlice[int32](a, 4)
+	}
+
+	before := a.Stats()
+	buildPair(a)
+	d := a.Stats().Sub(before)
+
+	fmt.Printf("%d allocations, %d bytes\n", d.Allocations, d.Bytes)
+	// Output: 2 allocations, 24 bytes
+}
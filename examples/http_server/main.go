@@ -38,8 +38,7 @@ func processRequest(req Request) Response {
 		})
 
 		// Simulate processing
-		buf := tempBuffer.Get()
-		copy(buf, req.Body)
+		tempBuffer.Copy(req.Body)
 
 		results := parseResults.Get()
 		results.Params["method"] = req.Method
@@ -34,21 +34,19 @@ func parseJSON(jsonData []byte) map[string]interface{} {
 
 		// Use arena for temporary processing buffers
 		processBuffer := safearena.AllocSlice[byte](a, 1024)
-		tempNodes := make([]safearena.Ptr[Node], 0, 10)
+		var tempNodes safearena.PtrSlice[Node]
 
 		// Process each key-value pair
 		for k, v := range data {
 			// Create temporary node in arena
-			node := safearena.Alloc(a, Node{
+			tempNodes.Append(a, Node{
 				Type:  "field",
 				Key:   k,
 				Value: v,
 			})
-			tempNodes = append(tempNodes, node)
 
 			// Use buffer for temporary operations
-			buf := processBuffer.Get()
-			copy(buf, []byte(k))
+			processBuffer.Copy([]byte(k))
 
 			// Extract final result (heap-allocated)
 			result[k] = v
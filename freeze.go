@@ -0,0 +1,158 @@
+package safearena
+
+import (
+	"unsafe"
+)
+
+// Set stores value at the pointer.
+//
+// Panics if the arena has been freed or reset, or (in debug mode) if the
+// value has been frozen with Freeze.
+func (p Ptr[T]) Set(value T) {
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(p.arena.staleError(p.gen, stack, site))
+	}
+	if p.arena.debug != nil && p.arena.debug.isFrozen(unsafe.Pointer(p.ptr), p.arena.floor.Load()) {
+		stack := captureStack(2)
+		panic(errorWithHint(p.arena, "write to frozen arena value", stack, WriteToFrozen))
+	}
+	*p.ptr = value
+}
+
+// Freeze marks the value read-only: later writes through Set panic with
+// "write to frozen arena value", while Get and Deref keep working. Use it to
+// catch accidental mutation of data handed off as read-only within a scope.
+//
+// Freeze is enforced only for arenas created while Debug is set; elsewhere it
+// is a no-op. Writes through the *T returned by Get are not detected.
+//
+// Panics if the arena has been freed or reset.
+//
+// Example:
+//
+//	cfg := safearena.Alloc(a, buildConfig())
+//	cfg.Freeze()
+//	publish(cfg)
+//	cfg.Set(Config{}) // Panics in debug mode
+func (p Ptr[T]) Freeze() {
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(p.arena.staleError(p.gen, stack, site))
+	}
+	if p.arena.debug != nil {
+		p.arena.debug.freeze(unsafe.Pointer(p.ptr), p.arena.floor.Load())
+	}
+}
+
+// SetAt stores value at index i.
+//
+// Panics if the arena has been freed or reset, if i is out of range, or (in
+// debug mode) if the slice has been frozen with Freeze.
+func (s Slice[T]) SetAt(i int, value T) {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if s.arena.debug != nil && s.arena.debug.isFrozen(unsafe.Pointer(unsafe.SliceData(s.slice)), s.arena.floor.Load()) {
+		stack := captureStack(2)
+		panic(errorWithHint(s.arena, "write to frozen arena value", stack, WriteToFrozen))
+	}
+	if uint(i) >= uint(len(s.slice)) {
+		panic(indexError(i, len(s.slice)))
+	}
+	s.slice[i] = value
+}
+
+// Copy copies src into the slice, as the builtin copy does, and returns the
+// number of elements copied: the smaller of len(src) and the slice's length.
+//
+// Panics if the arena has been freed or reset, or (in debug mode) if the
+// slice has been frozen with Freeze.
+//
+// Example:
+//
+//	buf := safearena.AllocSlice[byte](a, len(payload))
+//	buf.Copy(payload)
+func (s Slice[T]) Copy(src []T) int {
+	s.checkWrite()
+	return copy(s.slice, src)
+}
+
+// Fill sets every element of the slice to v.
+//
+// Panics if the arena has been freed or reset, or (in debug mode) if the
+// slice has been frozen with Freeze.
+//
+// Example:
+//
+//	row := safearena.AllocSlice[byte](a, width)
+//	row.Fill(0xFF)
+func (s Slice[T]) Fill(v T) {
+	s.checkWrite()
+	for i := range s.slice {
+		s.slice[i] = v
+	}
+}
+
+// checkWrite panics unless the slice may be written: its arena is live and,
+// in debug mode, it has not been frozen. It is called directly from exported
+// methods, which are reported as the call site.
+func (s Slice[T]) checkWrite() {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(3)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if s.arena.debug != nil && s.arena.debug.isFrozen(unsafe.Pointer(unsafe.SliceData(s.slice)), s.arena.floor.Load()) {
+		stack := captureStack(3)
+		panic(errorWithHint(s.arena, "write to frozen arena value", stack, WriteToFrozen))
+	}
+}
+
+// Freeze marks the slice read-only: later writes through SetAt panic with
+// "write to frozen arena value", while Get keeps working. Freezing applies
+// to the whole allocation: writes through any view of it (see Slice.Slice)
+// panic too, and freezing a view freezes the slice it was cut from.
+//
+// Like Ptr.Freeze, it is enforced only for arenas created while Debug is set,
+// and writes through the []T returned by Get are not detected.
+//
+// Panics if the arena has been freed or reset.
+func (s Slice[T]) Freeze() {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if s.arena.debug != nil {
+		s.arena.debug.freeze(unsafe.Pointer(unsafe.SliceData(s.slice)), s.arena.floor.Load())
+	}
+}
+
+// Freeze makes the arena read-only for the build-then-read pattern: once a
+// data structure is built, later allocations from the arena (Alloc,
+// AllocSlice, AllocBytes, container growth, ...) panic with "allocation in
+// frozen arena", while Get and Deref on existing values keep working.
+// Free still works on a frozen arena, and Reset unfreezes it.
+//
+// Unlike Ptr.Freeze, Arena.Freeze is enforced in all modes. It does not stop
+// writes to existing values.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	index := buildIndex(a)
+//	a.Freeze()
+//	serve(index) // Any allocation from a now panics
+func (a *Arena) Freeze() {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "Freeze after free", stack, AllocAfterFree))
+	}
+	a.frozen.Store(true)
+}
@@ -0,0 +1,183 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// expectFrozenPanic fails the test unless fn panics with a frozen-write error
+func expectFrozenPanic(t *testing.T, fn func()) {
+	t.Helper()
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "write to frozen arena value") {
+			t.Errorf("expected frozen write panic, got %q", msg)
+		}
+	}()
+	fn()
+}
+
+func TestPtrFreeze(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	p := Alloc(a, 1)
+	p.Set(2)
+	p.Freeze()
+
+	if p.Deref() != 2 {
+		t.Errorf("expected frozen value to stay readable, got %d", p.Deref())
+	}
+	expectFrozenPanic(t, func() { p.Set(3) })
+	if p.Deref() != 2 {
+		t.Errorf("expected rejected write to leave value unchanged, got %d", p.Deref())
+	}
+
+	// Other allocations are unaffected
+	q := Alloc(a, 1)
+	q.Set(5)
+	if q.Deref() != 5 {
+		t.Errorf("expected 5, got %d", q.Deref())
+	}
+}
+
+func TestSliceFreeze(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	s := AllocSlice[int](a, 4)
+	s.SetAt(0, 7)
+	s.Freeze()
+
+	if s.Get()[0] != 7 {
+		t.Errorf("expected frozen slice to stay readable, got %d", s.Get()[0])
+	}
+	expectFrozenPanic(t, func() { s.SetAt(1, 8) })
+	expectFrozenPanic(t, func() { s.Copy([]int{8}) })
+	expectFrozenPanic(t, func() { s.Fill(8) })
+
+	other := AllocSlice[int](a, 4)
+	other.SetAt(1, 8)
+	if other.Get()[1] != 8 {
+		t.Errorf("expected 8, got %d", other.Get()[1])
+	}
+}
+
+func TestSliceFreezeViews(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	s := AllocSlice[int](a, 4)
+	s.Freeze()
+	expectFrozenPanic(t, func() { s.Slice(1, 3).SetAt(0, 1) })
+	expectFrozenPanic(t, func() { s.Slice(2, 4).Fill(1) })
+
+	u := AllocSlice[int](a, 4)
+	u.Slice(1, 3).Freeze()
+	expectFrozenPanic(t, func() { u.SetAt(0, 1) })
+}
+
+func TestFreezeWithoutDebug(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p := Alloc(a, 1)
+	p.Freeze()
+	p.Set(2) // Not enforced outside debug mode
+
+	if p.Deref() != 2 {
+		t.Errorf("expected 2, got %d", p.Deref())
+	}
+}
+
+func TestSetAfterFree(t *testing.T) {
+	a := New()
+	p := Alloc(a, 1)
+	s := AllocSlice[int](a, 1)
+	a.Free()
+
+	tests := []struct {
+		name string
+		use  func()
+	}{
+		{"set", func() { p.Set(2) }},
+		{"set at", func() { s.SetAt(0, 2) }},
+		{"freeze ptr", func() { p.Freeze() }},
+		{"freeze slice", func() { s.Freeze() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use()
+		})
+	}
+}
+
+func TestArenaFreeze(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p := Alloc(a, 42)
+	s := AllocSlice[int](a, 3)
+	a.Freeze()
+
+	allocs := map[string]func(){
+		"Alloc":      func() { Alloc(a, 1) },
+		"AllocSlice": func() { AllocSlice[int](a, 1) },
+		"AllocBytes": func() { a.AllocBytes(8) },
+		"Reserve":    func() { a.Reserve(64) },
+	}
+	for name, alloc := range allocs {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "allocation in frozen arena") {
+					t.Errorf("expected frozen arena panic, got %q", msg)
+				}
+			}()
+			alloc()
+		})
+	}
+
+	// Reads keep working
+	if got := p.Deref(); got != 42 {
+		t.Errorf("expected 42, got %d", got)
+	}
+	if got := len(s.Get()); got != 3 {
+		t.Errorf("expected length 3, got %d", got)
+	}
+}
+
+func TestArenaFreezeThenFree(t *testing.T) {
+	a := New()
+	a.Freeze()
+	a.Free()
+
+	if !a.IsFreed() {
+		t.Error("expected frozen arena to be freed")
+	}
+}
+
+func TestArenaFreezeReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	a.Freeze()
+	a.Reset()
+
+	if got := Alloc(a, 7).Deref(); got != 7 {
+		t.Errorf("expected allocation after reset to succeed, got %d", got)
+	}
+}
@@ -0,0 +1,120 @@
+package safearena
+
+import "fmt"
+
+// Grid is a fixed-size two-dimensional array in one contiguous arena
+// allocation, stored row by row. It takes the y*width+x index arithmetic out
+// of image and numeric code, bounds-checks both coordinates, and fits
+// per-frame arenas: allocate a Grid for each frame and free it with the
+// frame.
+//
+// At and Set check the arena's lifetime on every call, which dominates in
+// per-pixel loops; take each row once with Row and index into it instead,
+// which runs at the speed of a plain slice.
+//
+// Every method panics if the arena has been freed or reset.
+//
+// Example:
+//
+//	frame := safearena.NewGrid[byte](a, 1920, 1080)
+//	frame.Set(0, 0, 255)
+//	for y := 0; y < frame.Height(); y++ {
+//	    row := frame.Row(y).Get() // One scanline as a []byte
+//	    for x := range row {
+//	        row[x] ^= byte(x ^ y)
+//	    }
+//	}
+type Grid[T any] struct {
+	arena  *Arena
+	gen    uint64 // Arena generation the cells belong to
+	cells  []T    // Arena-backed; width*height, row-major
+	width  int
+	height int
+}
+
+// NewGrid allocates a zeroed width×height grid in the arena.
+//
+// Panics if width or height is negative, or if the arena has already been
+// freed.
+func NewGrid[T any](a *Arena, width, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("safearena: negative Grid dimensions %dx%d", width, height))
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return &Grid[T]{
+		arena:  a,
+		gen:    a.gen.Load(),
+		cells:  makeSlice[T](a, width*height, width*height),
+		width:  width,
+		height: height,
+	}
+}
+
+// At returns a pointer to the cell at column x of row y. Like Ptr.Get, the
+// pointer must not be kept past the arena's lifetime.
+//
+// Panics if x or y is out of range.
+func (g *Grid[T]) At(x, y int) *T {
+	g.check()
+	return &g.cells[g.index(x, y)]
+}
+
+// Set stores v in the cell at column x of row y.
+//
+// Panics if x or y is out of range.
+func (g *Grid[T]) Set(x, y int, v T) {
+	g.check()
+	g.cells[g.index(x, y)] = v
+}
+
+// Row returns row y as a lifetime-checked slice of width cells. It shares
+// storage with the grid, and its capacity ends at the row, so appending to it
+// never overwrites the next row.
+//
+// Panics if y is out of range.
+func (g *Grid[T]) Row(y int) Slice[T] {
+	g.check()
+	if y < 0 || y >= g.height {
+		panic(fmt.Sprintf("safearena: Grid row %d out of range for %dx%d grid", y, g.width, g.height))
+	}
+	start := y * g.width
+	return Slice[T]{slice: g.cells[start : start+g.width : start+g.width], arena: g.arena, gen: g.gen}
+}
+
+// Cells returns the whole grid as one lifetime-checked row-major slice, for
+// operations that don't care about coordinates, such as filling or copying
+func (g *Grid[T]) Cells() Slice[T] {
+	g.check()
+	return Slice[T]{slice: g.cells, arena: g.arena, gen: g.gen}
+}
+
+// Width returns the number of columns
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// index converts coordinates to an offset in cells, panicking if either is
+// out of range; checking them separately catches an x past the end of a row
+// that would otherwise land in the next row
+func (g *Grid[T]) index(x, y int) int {
+	if x < 0 || x >= g.width || y < 0 || y >= g.height {
+		panic(fmt.Sprintf("safearena: Grid index (%d, %d) out of range for %dx%d grid", x, y, g.width, g.height))
+	}
+	return y*g.width + x
+}
+
+// check panics if the grid's arena has been freed or reset
+func (g *Grid[T]) check() {
+	if g.arena.freed.Load() || !g.arena.live(g.gen) {
+		stack := captureStack(3)
+		panic(g.arena.staleError(g.gen, stack, nil))
+	}
+}
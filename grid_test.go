@@ -0,0 +1,136 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	g := NewGrid[int](a, 4, 3)
+	if g.Width() != 4 || g.Height() != 3 || g.Cells().Len() != 12 {
+		t.Fatalf("expected a 4x3 grid, got %dx%d with %d cells", g.Width(), g.Height(), g.Cells().Len())
+	}
+	for y := 0; y < g.Height(); y++ {
+		for x := 0; x < g.Width(); x++ {
+			g.Set(x, y, 10*y+x)
+		}
+	}
+
+	if v := *g.At(3, 2); v != 23 {
+		t.Errorf("expected At(3, 2) = 23, got %d", v)
+	}
+	*g.At(0, 1) = -1
+	row := g.Row(1).Get()
+	if fmt.Sprint(row) != "[-1 11 12 13]" {
+		t.Errorf("unexpected row 1: %v", row)
+	}
+	if cap(row) != 4 {
+		t.Errorf("expected row capacity to end at the row, got %d", cap(row))
+	}
+	_ = append(row, 99)
+	if *g.At(0, 2) != 20 {
+		t.Error("expected appending to a row to leave the next row alone")
+	}
+	if cells := g.Cells().Get(); cells[4] != -1 || cells[11] != 23 {
+		t.Errorf("expected row-major cells, got %v", cells)
+	}
+}
+
+func TestGridBounds(t *testing.T) {
+	a := New()
+	defer a.Free()
+	g := NewGrid[byte](a, 4, 3)
+
+	for name, tc := range map[string]struct {
+		access func()
+		want   string
+	}{
+		"x past row end": {func() { g.At(4, 0) }, "Grid index (4, 0) out of range for 4x3 grid"},
+		"negative x":     {func() { g.Set(-1, 1, 0) }, "Grid index (-1, 1) out of range"},
+		"y past end":     {func() { g.At(0, 3) }, "Grid index (0, 3) out of range"},
+		"row past end":   {func() { g.Row(3) }, "Grid row 3 out of range for 4x3 grid"},
+		"negative size":  {func() { NewGrid[byte](a, -1, 2) }, "negative Grid dimensions -1x2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.access()
+		})
+	}
+}
+
+func TestGridAfterFree(t *testing.T) {
+	a := New()
+	g := NewGrid[float64](a, 2, 2)
+	row := g.Row(0)
+	a.Free()
+
+	for name, access := range map[string]func(){
+		"At":  func() { g.At(0, 0) },
+		"Set": func() { g.Set(0, 0, 1) },
+		"Row": func() { g.Row(0) },
+		"row": func() { row.Get() },
+		"New": func() { NewGrid[float64](a, 2, 2) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "after free") {
+					t.Errorf("expected after free panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func BenchmarkGridFill(b *testing.B) {
+	const width, height = 640, 480
+	for i := 0; i < b.N; i++ {
+		a := New()
+		g := NewGrid[byte](a, width, height)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				g.Set(x, y, byte(x+y))
+			}
+		}
+		a.Free()
+	}
+}
+
+func BenchmarkGridFillRows(b *testing.B) {
+	const width, height = 640, 480
+	for i := 0; i < b.N; i++ {
+		a := New()
+		g := NewGrid[byte](a, width, height)
+		for y := 0; y < height; y++ {
+			row := g.Row(y).Get()
+			for x := range row {
+				row[x] = byte(x + y)
+			}
+		}
+		a.Free()
+	}
+}
+
+func BenchmarkFlatSliceFill(b *testing.B) {
+	const width, height = 640, 480
+	for i := 0; i < b.N; i++ {
+		a := New()
+		pixels := AllocSlice[byte](a, width*height).Get()
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				pixels[y*width+x] = byte(x + y)
+			}
+		}
+		a.Free()
+	}
+}
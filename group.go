@@ -0,0 +1,88 @@
+package safearena
+
+import (
+	"errors"
+	"sync"
+)
+
+// Group frees a set of arenas that share one lifetime, such as the per-task
+// arenas of a fan-out/fan-in pipeline stage. The zero value is ready to use,
+// and a Group is safe for concurrent use.
+//
+// Example:
+//
+//	var g safearena.Group
+//	defer g.FreeAll()
+//	for _, task := range tasks {
+//	    a := safearena.New()
+//	    g.Add(a)
+//	    go process(a, task)
+//	}
+type Group struct {
+	mu     sync.Mutex
+	arenas []*Arena
+	freed  bool
+}
+
+// Add registers a with the group so that FreeAll frees it.
+//
+// Panics if FreeAll has already been called.
+func (g *Group) Add(a *Arena) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.freed {
+		panic("safearena: Group.Add called after FreeAll")
+	}
+	g.arenas = append(g.arenas, a)
+}
+
+// FreeAll frees every registered arena. Unlike calling Free in a loop, it
+// does not stop at the first failure: double-free panics (from an arena that
+// was already freed elsewhere) and errors returned by OnFree callbacks are
+// collected and returned together, joined with errors.Join. Other panics are
+// propagated after the remaining arenas have been freed.
+//
+// FreeAll is idempotent: calls after the first do nothing and return nil.
+func (g *Group) FreeAll() error {
+	g.mu.Lock()
+	arenas := g.arenas
+	done := g.freed
+	g.arenas = nil
+	g.freed = true
+	g.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	var errs []error
+	var fatal any
+	for _, a := range arenas {
+		r, err := freeCollect(a)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if r != nil && fatal == nil {
+			fatal = r
+		}
+	}
+	if fatal != nil {
+		panic(fatal)
+	}
+	return errors.Join(errs...)
+}
+
+// freeCollect frees a, returning any panic value other than an ArenaError
+// as r and an ArenaError panic or OnFree callback error as err
+func freeCollect(a *Arena) (r any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			if ae, ok := v.(*ArenaError); ok {
+				err = ae
+			} else {
+				r = v
+			}
+		}
+	}()
+	a.Free()
+	return nil, a.FreeErr()
+}
@@ -0,0 +1,71 @@
+package safearena
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGroupFreeAll(t *testing.T) {
+	var g Group
+	arenas := make([]*Arena, 10)
+	for i := range arenas {
+		arenas[i] = New()
+		Alloc(arenas[i], i)
+		g.Add(arenas[i])
+	}
+	arenas[3].Free() // Freed outside the group
+
+	err := g.FreeAll()
+	var ae *ArenaError
+	if !errors.As(err, &ae) || ae.Kind != DoubleFree {
+		t.Fatalf("expected double free error, got %v", err)
+	}
+	if ae.ArenaID != arenas[3].id {
+		t.Errorf("expected error for arena %d, got arena %d", arenas[3].id, ae.ArenaID)
+	}
+	for i, a := range arenas {
+		if !a.IsFreed() {
+			t.Errorf("expected arena %d to be freed", i)
+		}
+	}
+
+	if err := g.FreeAll(); err != nil {
+		t.Errorf("expected second FreeAll to return nil, got %v", err)
+	}
+}
+
+func TestGroupFreeAllCollectsErrors(t *testing.T) {
+	var g Group
+	a, b, c := New(), New(), New()
+	b.OnFree(func() error { return errors.New("close failed") })
+	a.Free()
+	c.Free()
+	g.Add(a)
+	g.Add(b)
+	g.Add(c)
+
+	err := g.FreeAll()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	msg := err.Error()
+	if strings.Count(msg, "double free") != 2 || !strings.Contains(msg, "close failed") {
+		t.Errorf("expected two double frees and the callback error, got %q", msg)
+	}
+	if !b.IsFreed() {
+		t.Error("expected arena b to be freed")
+	}
+}
+
+func TestGroupAddAfterFreeAll(t *testing.T) {
+	var g Group
+	g.FreeAll()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic adding to a freed group")
+		}
+	}()
+	g.Add(New())
+}
@@ -0,0 +1,172 @@
+package safearena
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// installHooks sets OnAlloc and OnFree for the duration of a test
+func installHooks(t *testing.T, onAlloc func(uint64, int), onFree func(uint64, int)) {
+	t.Helper()
+	OnAlloc, OnFree = onAlloc, onFree
+	t.Cleanup(func() { OnAlloc, OnFree = nil, nil })
+}
+
+func TestHooks(t *testing.T) {
+	type event struct {
+		id    uint64
+		bytes int
+	}
+	var allocs, frees []event
+	installHooks(t,
+		func(id uint64, bytes int) { allocs = append(allocs, event{id, bytes}) },
+		func(id uint64, total int) { frees = append(frees, event{id, total}) },
+	)
+
+	a := New()
+	b := New()
+	_ = Alloc(a, int64(1))
+	_ = AllocSlice[byte](b, 16)
+	_ = AllocSlice[int32](a, 4)
+	a.Free()
+	b.Free()
+
+	wantAllocs := []event{{a.id, 8}, {b.id, 16}, {a.id, 16}}
+	if len(allocs) != len(wantAllocs) {
+		t.Fatalf("expected %d alloc events, got %v", len(wantAllocs), allocs)
+	}
+	for i, want := range wantAllocs {
+		if allocs[i] != want {
+			t.Errorf("alloc event %d: expected %+v, got %+v", i, want, allocs[i])
+		}
+	}
+
+	wantFrees := []event{{a.id, 24}, {b.id, 16}}
+	if len(frees) != len(wantFrees) {
+		t.Fatalf("expected %d free events, got %v", len(wantFrees), frees)
+	}
+	for i, want := range wantFrees {
+		if frees[i] != want {
+			t.Errorf("free event %d: expected %+v, got %+v", i, want, frees[i])
+		}
+	}
+}
+
+func TestHooksNotCalledOnFailure(t *testing.T) {
+	calls := 0
+	installHooks(t, func(uint64, int) { calls++ }, nil)
+
+	a := NewWithLimit(4)
+	defer a.Free()
+
+	if _, err := TryAlloc(a, int64(1)); err == nil {
+		t.Fatal("expected budget error")
+	}
+	if calls != 0 {
+		t.Errorf("expected no OnAlloc call for a rejected allocation, got %d", calls)
+	}
+}
+
+// Measure the cost of the hook nil checks on the allocation hot path
+// recordingObserver records lifecycle events as strings
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) Created(id uint64) {
+	o.events = append(o.events, fmt.Sprintf("created %d", id))
+}
+
+func (o *recordingObserver) Freed(id uint64, stats Stats) {
+	o.events = append(o.events, fmt.Sprintf("freed %d: %d allocs, %d bytes", id, stats.Allocations, stats.Bytes))
+}
+
+func TestObserver(t *testing.T) {
+	obs := &recordingObserver{}
+	SetObserver(obs)
+	t.Cleanup(func() { SetObserver(nil) })
+
+	a := New()
+	Alloc(a, int64(1))
+	AllocSlice[int32](a, 4)
+	a.Free()
+
+	b := New()
+	b.FreeAsync()
+
+	c := New()
+	c.TryFree()
+	c.TryFree() // Already freed: no second event
+
+	want := []string{
+		fmt.Sprintf("created %d", a.id),
+		fmt.Sprintf("freed %d: 2 allocs, 24 bytes", a.id),
+		fmt.Sprintf("created %d", b.id),
+		fmt.Sprintf("freed %d: 0 allocs, 0 bytes", b.id),
+		fmt.Sprintf("created %d", c.id),
+		fmt.Sprintf("freed %d: 0 allocs, 0 bytes", c.id),
+	}
+	if !slices.Equal(obs.events, want) {
+		t.Errorf("expected events %q, got %q", want, obs.events)
+	}
+}
+
+func BenchmarkAllocHooks(b *testing.B) {
+	run := func(b *testing.B) {
+		a := New()
+		defer a.Free()
+		for i := 0; i < b.N; i++ {
+			if i%1024 == 0 {
+				a.Reset()
+			}
+			_ = Alloc(a, i)
+		}
+	}
+
+	b.Run("no hooks", run)
+
+	b.Run("hooks", func(b *testing.B) {
+		OnAlloc = func(uint64, int) {}
+		defer func() { OnAlloc = nil }()
+		run(b)
+	})
+}
+
+func TestOnLeak(t *testing.T) {
+	leaked := make(chan uint64, 16)
+	OnLeak = func(id uint64, stats Stats) {
+		select {
+		case leaked <- id:
+		default:
+		}
+	}
+	t.Cleanup(func() { OnLeak = nil })
+	before := LeaksDetected.Load()
+
+	id := func() uint64 {
+		a := NewWithFinalizer()
+		_ = Alloc(a, [64]byte{})
+		return a.ID() // Dropped without Free
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case got := <-leaked:
+			if got != id {
+				continue // Leaked by another test
+			}
+			if LeaksDetected.Load() <= before {
+				t.Error("expected LeaksDetected to be incremented")
+			}
+			return
+		case <-deadline:
+			t.Fatal("leak callback did not fire")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
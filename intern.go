@@ -0,0 +1,65 @@
+package safearena
+
+// Interner deduplicates strings into arena storage: interning the same
+// content twice returns StringRefs that share one arena copy. Parsers of
+// formats with many repeated keys (JSON object keys, CSV headers, log field
+// names) store each distinct string once instead of once per occurrence.
+//
+// The lookup table is an ArenaMap, so the table and every interned string
+// share the arena's lifetime.
+//
+// An Interner is not safe for concurrent use.
+// Every method panics if the arena has been freed or reset.
+type Interner struct {
+	arena *Arena
+	gen   uint64 // Arena generation the table belongs to
+	table *ArenaMap[string, StringRef]
+}
+
+// NewInterner creates an empty Interner.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	in := safearena.NewInterner(a)
+//	for i, rec := range records {
+//	    names[i] = in.Intern(strings.ToLower(rec.Name)) // One copy per distinct name
+//	}
+func NewInterner(a *Arena) *Interner {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return &Interner{
+		arena: a,
+		gen:   a.gen.Load(),
+		table: NewMap[string, StringRef](a),
+	}
+}
+
+// Intern returns a StringRef for s, copying s into the arena only the first
+// time its content is seen
+func (in *Interner) Intern(s string) StringRef {
+	in.check()
+	if ref, ok := in.table.Get(s); ok {
+		return ref
+	}
+	ref := AllocString(in.arena, s)
+	in.table.Set(ref.Get(), ref) // Key the table by the arena copy, not s
+	return ref
+}
+
+// Len returns the number of distinct strings interned
+func (in *Interner) Len() int {
+	in.check()
+	return in.table.Len()
+}
+
+// check panics if the interner's arena has been freed or reset
+func (in *Interner) check() {
+	if in.arena.freed.Load() || !in.arena.live(in.gen) {
+		stack := captureStack(3)
+		panic(in.arena.staleError(in.gen, stack, nil))
+	}
+}
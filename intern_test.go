@@ -0,0 +1,50 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestInterner(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	in := NewInterner(a)
+	first := in.Intern("user_id")
+	allocs := a.Stats().Allocations
+
+	for range 1000 {
+		key := strings.ToLower("USER_ID") // A fresh heap string each time
+		ref := in.Intern(key)
+		if ref.data != first.data {
+			t.Fatal("expected repeated strings to share one arena copy")
+		}
+	}
+	if got := a.Stats().Allocations; got != allocs {
+		t.Errorf("expected no allocations for repeated strings, got %d", got-allocs)
+	}
+
+	other := in.Intern("name")
+	if other.Get() != "name" || other.data == first.data {
+		t.Error("expected a distinct string to get its own copy")
+	}
+	if in.Len() != 2 {
+		t.Errorf("expected 2 distinct strings, got %d", in.Len())
+	}
+}
+
+func TestInternerAfterFree(t *testing.T) {
+	a := New()
+	in := NewInterner(a)
+	in.Intern("key")
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	in.Intern("key")
+}
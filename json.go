@@ -0,0 +1,37 @@
+package safearena
+
+import (
+	"encoding/json"
+)
+
+// UnmarshalArena decodes JSON data into a new arena-allocated T.
+// Malformed JSON, or JSON that does not match T, returns an error and a zero
+// Ptr instead of panicking.
+//
+// The top-level T lives in the arena and is released in bulk with it.
+// encoding/json has no allocator hook, so slices, maps, strings, and pointers
+// inside T are still allocated on the heap by the decoder; flatten hot
+// structures into fixed-size fields where that matters.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	req, err := safearena.UnmarshalArena[Request](a, body)
+//	if err != nil {
+//	    return err
+//	}
+//	handle(req.Get())
+func UnmarshalArena[T any](a *Arena, data []byte) (Ptr[T], error) {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	var zero T
+	p := Alloc(a, zero)
+	if err := json.Unmarshal(data, p.ptr); err != nil {
+		return Ptr[T]{}, err
+	}
+	return p, nil
+}
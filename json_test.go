@@ -0,0 +1,77 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type jsonOrder struct {
+	ID    int      `json:"id"`
+	Items []string `json:"items"`
+	Total float64  `json:"total"`
+}
+
+func TestUnmarshalArena(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p, err := UnmarshalArena[jsonOrder](a, []byte(`{"id": 7, "items": ["a", "b"], "total": 9.5}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	order := p.Get()
+	if order.ID != 7 || order.Total != 9.5 {
+		t.Errorf("expected id 7 total 9.5, got %+v", order)
+	}
+	if len(order.Items) != 2 || order.Items[1] != "b" {
+		t.Errorf("expected items [a b], got %v", order.Items)
+	}
+	if got := a.Stats().Allocations; got != 1 {
+		t.Errorf("expected the top-level value to be arena-allocated, got %d allocations", got)
+	}
+}
+
+func TestUnmarshalArenaMalformed(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"syntax", `{"id": `},
+		{"type mismatch", `{"id": "seven"}`},
+		{"empty", ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := UnmarshalArena[jsonOrder](a, []byte(tt.data))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if p.arena != nil {
+				t.Error("expected zero Ptr on error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalArenaAfterFree(t *testing.T) {
+	a := New()
+	p, err := UnmarshalArena[jsonOrder](a, []byte(`{"id": 1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	_ = p.Get()
+}
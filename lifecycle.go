@@ -0,0 +1,256 @@
+package safearena
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// cleanupState holds the OnFree callbacks of an arena
+type cleanupState struct {
+	mu  sync.Mutex
+	fns []func() error
+	err error // Joined callback errors from the most recent Free or Reset
+}
+
+// OnFree registers fn to run when the arena is next freed or reset.
+// Callbacks run once, in reverse registration order (like defer), after the
+// arena's values have been invalidated, so they must not access arena memory.
+// Errors returned by callbacks are collected and reported by FreeErr.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	f, _ := os.CreateTemp("", "req-*")
+//	a.OnFree(func() error { return os.Remove(f.Name()) })
+func (a *Arena) OnFree(fn func() error) {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "OnFree after free", stack, AllocAfterFree))
+	}
+
+	a.cleanup.mu.Lock()
+	a.cleanup.fns = append(a.cleanup.fns, fn)
+	a.cleanup.mu.Unlock()
+}
+
+// FreeErr returns the errors returned by OnFree callbacks during the most
+// recent Free or Reset, joined with errors.Join, or nil if all succeeded.
+func (a *Arena) FreeErr() error {
+	a.cleanup.mu.Lock()
+	defer a.cleanup.mu.Unlock()
+	return a.cleanup.err
+}
+
+// CloseFunc returns a function that frees the arena and reports the outcome
+// as an error instead of panicking, for error-returning cleanup: deferred
+// closers, errgroup tasks, and resource-management helpers that collect
+// func() error values. The function returns FreeErr after freeing, or a
+// DoubleFree *ArenaError if the arena was already freed, as TryFree would
+// report false.
+//
+// Example:
+//
+//	g.Go(func() (err error) {
+//	    a := safearena.New()
+//	    closeArena := a.CloseFunc()
+//	    defer func() { err = errors.Join(err, closeArena()) }()
+//	    return process(a, item)
+//	})
+func (a *Arena) CloseFunc() func() error {
+	return func() error {
+		if !a.tryFree(3, (*backing).Free) && !a.expired() {
+			stack := captureStack(2)
+			return errorWithHint(a, "double free", stack, DoubleFree)
+		}
+		return a.FreeErr()
+	}
+}
+
+// runCleanups runs and clears the registered OnFree callbacks
+func (a *Arena) runCleanups() {
+	a.cleanup.mu.Lock()
+	fns := a.cleanup.fns
+	a.cleanup.fns = nil
+	a.cleanup.mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		if err := fns[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	a.cleanup.mu.Lock()
+	a.cleanup.err = errors.Join(errs...)
+	a.cleanup.mu.Unlock()
+}
+
+// Reset releases all allocations and makes the arena ready for reuse, so one
+// *Arena, with its configuration (debug mode, limit, name, reader lock), can
+// serve many units of work in turn. It is not cheaper than a new arena: Go
+// arenas cannot be reused once released, so Reset frees the underlying arena
+// and creates another, the same work as Free followed by New.
+//
+// Ptr and Slice values allocated before the Reset become invalid and panic
+// with "use after reset" on access. OnFree callbacks run as they do on Free,
+// and Stats (and with it the NewWithLimit budget) start again from zero.
+// A frozen arena (see Arena.Freeze) accepts allocations again after Reset.
+//
+// Reset must not be called concurrently with other operations on the arena.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	a := safearena.New()
+//	defer a.Free()
+//	for _, req := range requests {
+//	    handle(a, req)
+//	    a.Reset() // Reuse the arena for the next request
+//	}
+func (a *Arena) Reset() {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "reset after free", stack, AllocAfterFree))
+	}
+	if a.readers != nil {
+		a.readers.Lock()
+		defer a.readers.Unlock()
+	}
+
+	gen := a.gen.Add(1) - 1 // Invalidate existing Ptr and Slice values
+	floor := a.floor.Swap(gen + 1)
+	a.released.Store(nil)
+	a.taken.Store(nil)
+	a.runCleanups()
+	if a.debug != nil {
+		a.debug.poison(floor, gen)
+		a.debug.mu.Lock()
+		a.debug.sizes = [len(a.debug.sizes)]int{}
+		a.debug.mu.Unlock()
+	}
+	a.inner.Free()
+	a.inner = newBacking()
+	a.reserved = nil
+	a.frozen.Store(false)
+	a.stats.allocs.Store(0)
+	a.stats.bytes.Store(0)
+}
+
+// Reserve is a best-effort hint that the arena will soon need about bytes of
+// byte-buffer memory. It allocates that much from the underlying arena in one
+// step; later byte allocations (AllocBytes, NewWriter, NewBuffer, BlobStore,
+// StringBuilder growth) are carved out of the reservation until it runs out,
+// instead of each going to the underlying arena. Typed allocations (Alloc,
+// AllocSlice, ...) do not use the reservation, because pointer-bearing values
+// cannot live in byte memory.
+//
+// Reserved bytes count toward Stats and the NewWithLimit budget only as they
+// are handed out. A Reset discards any unused reservation.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	a := safearena.New()
+//	defer a.Free()
+//	a.Reserve(8 << 20) // Expecting ~8MB of buffers
+func (a *Arena) Reserve(bytes int) {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	if a.frozen.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation in frozen arena", stack, AllocInFrozen))
+	}
+	if bytes <= len(a.reserved) {
+		return
+	}
+	a.reserved = backingMakeSlice[byte](a.inner, bytes, bytes)
+}
+
+// NewWithChunkSize creates an arena that reserves bytes of byte-buffer
+// memory up front (see Reserve), so the first bytes of AllocBytes, writer,
+// buffer, and blob storage come from one contiguous block.
+//
+// Go's arena API has no chunk-size setting, so this emulates one. For
+// reference, the runtime currently (Go 1.25, 64-bit) allocates arena memory in
+// fixed 8 MiB chunks, and any single allocation larger than a quarter chunk
+// (2 MiB) is not placed in the arena at all but on the garbage-collected heap,
+// which caps chunk waste at 25%. A reservation larger than 2 MiB is therefore
+// heap memory too: it still saves per-allocation work but not GC scanning.
+// TestChunking logs the observed behavior for a range of sizes.
+//
+// Panics if bytes is negative.
+//
+// Example:
+//
+//	a := safearena.NewWithChunkSize(1 << 20) // Requests build ~1 MiB of buffers
+//	defer a.Free()
+func NewWithChunkSize(bytes int) *Arena {
+	if bytes < 0 {
+		panic(fmt.Sprintf("safearena: negative chunk size %d", bytes))
+	}
+	a := New()
+	a.Reserve(bytes)
+	return a
+}
+
+// asyncFreeQueue bounds how many arenas FreeAsync can have waiting for
+// release; beyond that FreeAsync releases the memory inline
+const asyncFreeQueue = 64
+
+var (
+	asyncFreeOnce sync.Once
+	asyncFrees    chan *backing
+	pendingFrees  sync.WaitGroup // Arenas queued but not yet released
+)
+
+// freeWorker releases arenas queued by FreeAsync
+func freeWorker() {
+	for inner := range asyncFrees {
+		inner.Free()
+		pendingFrees.Done()
+	}
+}
+
+// FreeAsync is like Free but moves releasing the arena's memory off the
+// caller's path. The arena is marked freed and OnFree callbacks run before
+// FreeAsync returns, so use-after-free checks apply immediately; the
+// underlying memory is released later by a background worker.
+//
+// The trade-off is that the memory is held slightly longer than with Free.
+// If the worker falls behind, FreeAsync releases the memory inline rather
+// than letting unreleased arenas pile up.
+//
+// Panics on double free, including Free after FreeAsync and vice versa.
+//
+// Example:
+//
+//	a := safearena.New()
+//	defer a.FreeAsync() // Keep reclamation out of request latency
+func (a *Arena) FreeAsync() {
+	if !a.tryFree(3, releaseAsync) && !a.expired() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "double free", stack, DoubleFree))
+	}
+}
+
+// releaseAsync queues inner for the background worker, or releases it inline
+// if the queue is full
+func releaseAsync(inner *backing) {
+	asyncFreeOnce.Do(func() {
+		asyncFrees = make(chan *backing, asyncFreeQueue)
+		go freeWorker()
+	})
+	pendingFrees.Add(1)
+	select {
+	case asyncFrees <- inner:
+	default:
+		inner.Free()
+		pendingFrees.Done()
+	}
+}
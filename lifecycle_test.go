@@ -0,0 +1,445 @@
+package safearena
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func TestOnFreeOrder(t *testing.T) {
+	a := New()
+
+	var order []int
+	for i := range 3 {
+		a.OnFree(func() error {
+			order = append(order, i)
+			return nil
+		})
+	}
+	a.Free()
+
+	if len(order) != 3 || order[0] != 2 || order[1] != 1 || order[2] != 0 {
+		t.Errorf("expected callbacks in reverse order [2 1 0], got %v", order)
+	}
+	if err := a.FreeErr(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestOnFreeErrors(t *testing.T) {
+	a := New()
+
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	a.OnFree(func() error { return errA })
+	a.OnFree(func() error { return nil })
+	a.OnFree(func() error { return errB })
+	a.Free()
+
+	err := a.FreeErr()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected both callback errors, got %v", err)
+	}
+}
+
+func TestOnFreeAfterFree(t *testing.T) {
+	a := New()
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic registering OnFree after free")
+		}
+	}()
+	a.OnFree(func() error { return nil })
+}
+
+func TestCloseFunc(t *testing.T) {
+	errCleanup := errors.New("cleanup failed")
+	errTask := errors.New("task failed")
+
+	arenas := make([]*Arena, 4)
+	var g errgroup.Group
+	for i := range arenas {
+		a := New()
+		arenas[i] = a
+		if i == 0 {
+			a.OnFree(func() error { return errCleanup })
+		}
+		g.Go(func() (err error) {
+			closeArena := a.CloseFunc()
+			defer func() { err = errors.Join(err, closeArena()) }()
+
+			Alloc(a, i)
+			if i == 3 {
+				return errTask
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	for _, a := range arenas {
+		if !a.IsFreed() {
+			t.Error("expected every task's arena to be freed")
+		}
+	}
+	if !errors.Is(err, errCleanup) && !errors.Is(err, errTask) {
+		t.Errorf("expected a task or cleanup error, got %v", err)
+	}
+
+	var arenaErr *ArenaError
+	if err := arenas[1].CloseFunc()(); !errors.As(err, &arenaErr) || arenaErr.Kind != DoubleFree {
+		t.Errorf("expected a DoubleFree error from closing a freed arena, got %v", err)
+	} else if !strings.Contains(err.Error(), "lifecycle_test.go") {
+		t.Errorf("expected the error to point at the caller, got %q", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p := Alloc(a, 42)
+	s := AllocSlice[int](a, 4)
+
+	calls := 0
+	a.OnFree(func() error {
+		calls++
+		return nil
+	})
+	a.Reset()
+
+	if calls != 1 {
+		t.Errorf("expected OnFree callback on reset, got %d calls", calls)
+	}
+	if a.freed.Load() {
+		t.Error("expected arena to remain usable after reset")
+	}
+
+	// Allocations after reset work normally
+	q := Alloc(a, 7)
+	if q.Deref() != 7 {
+		t.Errorf("expected 7, got %d", q.Deref())
+	}
+
+	tests := []struct {
+		name string
+		use  func()
+	}{
+		{"ptr", func() { _ = p.Get() }},
+		{"slice", func() { _ = s.Get() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after reset") {
+					t.Errorf("expected use after reset panic, got %q", msg)
+				}
+			}()
+			tt.use()
+		})
+	}
+}
+
+func TestResetThenFree(t *testing.T) {
+	a := New()
+	p := Alloc(a, 1)
+	a.Reset()
+	a.Free()
+
+	// Freed takes precedence over reset in the error message
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	_ = p.Get()
+}
+
+func TestResetAfterFree(t *testing.T) {
+	a := New()
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on reset after free")
+		}
+	}()
+	a.Reset()
+}
+
+func TestResetClearsDerivedState(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	store := NewBlobStore(a)
+	_, h := store.Put([]byte("data"))
+	w := a.NewWriter(8)
+	a.Reset()
+
+	if store.Len() != 0 {
+		t.Errorf("expected empty store after reset, got %d blobs", store.Len())
+	}
+	if _, ok := store.Get(h); ok {
+		t.Error("expected blob to be gone after reset")
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after reset") {
+			t.Errorf("expected use after reset panic from writer, got %q", msg)
+		}
+	}()
+	w.WriteString("x")
+}
+
+func TestReserve(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	a.Reserve(1 << 10)
+	if stats := a.Stats(); stats.Allocations != 0 {
+		t.Errorf("expected reserve alone not to count as an allocation, got %d", stats.Allocations)
+	}
+
+	// Buffers carved from the reservation are distinct and zeroed
+	b1 := a.AllocBytes(100)
+	b2 := a.AllocBytes(100)
+	copy(b1, strings.Repeat("x", 100))
+	for i, b := range b2 {
+		if b != 0 {
+			t.Fatalf("expected zeroed byte at %d, got %d", i, b)
+		}
+	}
+	if len(a.reserved) != 1<<10-200 {
+		t.Errorf("expected 200 bytes carved from reservation, %d left", len(a.reserved))
+	}
+	if stats := a.Stats(); stats.Allocations != 2 || stats.Bytes != 200 {
+		t.Errorf("expected 2 allocations of 200 bytes, got %+v", stats)
+	}
+
+	// Larger than what is left falls back to the arena
+	_ = a.AllocBytes(1 << 10)
+	if len(a.reserved) != 1<<10-200 {
+		t.Error("expected oversized allocation to bypass the reservation")
+	}
+
+	a.Reset()
+	if a.reserved != nil {
+		t.Error("expected reset to discard the reservation")
+	}
+}
+
+func TestReserveAfterFree(t *testing.T) {
+	a := New()
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on reserve after free")
+		}
+	}()
+	a.Reserve(1024)
+}
+
+func TestNewWithChunkSize(t *testing.T) {
+	a := NewWithChunkSize(1 << 10)
+	defer a.Free()
+
+	if len(a.reserved) != 1<<10 {
+		t.Errorf("expected 1024 reserved bytes, got %d", len(a.reserved))
+	}
+	_ = a.AllocBytes(100)
+	if len(a.reserved) != 1<<10-100 {
+		t.Error("expected allocation to come from the reservation")
+	}
+}
+
+// chunkBytes is the runtime's arena chunk size on 64-bit platforms
+const chunkBytes = 8 << 20
+
+// TestChunking documents how the runtime spreads allocations of increasing
+// size over arena chunks. It counts allocations with the OnAlloc hook and
+// distinct chunks by address (chunks are chunkBytes-aligned), and logs both;
+// run with -v to see the table.
+func TestChunking(t *testing.T) {
+	if !ArenasEnabled() {
+		t.Skip("chunking applies only with GOEXPERIMENT=arenas")
+	}
+
+	var allocs int
+	OnAlloc = func(uint64, int) { allocs++ }
+	defer func() { OnAlloc = nil }()
+
+	for _, size := range []int{64, 4 << 10, 256 << 10, 1 << 20, 2 << 20, 3 << 20} {
+		a := New()
+		allocs = 0
+		chunks := make(map[uintptr]bool)
+		total := 0
+		for total < 16<<20 {
+			b := a.AllocBytes(size)
+			chunks[uintptr(unsafe.Pointer(unsafe.SliceData(b)))/chunkBytes] = true
+			total += size
+		}
+		a.Free()
+
+		t.Logf("%8d-byte allocations: %4d allocations, %2d distinct 8 MiB regions for %d MiB",
+			size, allocs, len(chunks), total>>20)
+
+		// Small allocations pack densely: 16 MiB needs only a few chunks
+		if size <= 256<<10 && len(chunks) > 4 {
+			t.Errorf("expected %d-byte allocations to share chunks, got %d regions", size, len(chunks))
+		}
+	}
+}
+
+// largeRequest allocates about 8MB in small pieces
+func largeRequest(a *Arena) {
+	for i := 0; i < 2048; i++ {
+		_ = a.AllocBytes(4096)
+	}
+}
+
+// Compare allocation events for a large request with and without Reserve
+func BenchmarkReserve(b *testing.B) {
+	b.Run("no reserve", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ScopedPtr(largeRequest)
+		}
+	})
+
+	b.Run("reserve", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ScopedPtr(func(a *Arena) {
+				a.Reserve(8 << 20)
+				largeRequest(a)
+			})
+		}
+	})
+}
+
+func TestValid(t *testing.T) {
+	a := New()
+	p := Alloc(a, 1)
+	s := AllocSlice[int](a, 1)
+
+	if a.IsFreed() || !p.Valid() || !s.Valid() {
+		t.Fatal("expected live arena and valid values")
+	}
+
+	a.Reset()
+	if a.IsFreed() {
+		t.Error("expected reset arena not to be freed")
+	}
+	if p.Valid() || s.Valid() {
+		t.Error("expected values from before reset to be invalid")
+	}
+
+	q := Alloc(a, 2)
+	a.Free()
+	if !a.IsFreed() {
+		t.Error("expected arena to be freed")
+	}
+	if q.Valid() {
+		t.Error("expected value to be invalid after free")
+	}
+
+	if (Ptr[int]{}).Valid() || (Slice[int]{}).Valid() {
+		t.Error("expected zero values to be invalid")
+	}
+}
+
+func TestFreeAsync(t *testing.T) {
+	a := New()
+	p := Alloc(a, 42)
+	called := false
+	a.OnFree(func() error {
+		called = true
+		return nil
+	})
+
+	a.FreeAsync()
+	if !a.IsFreed() {
+		t.Error("expected arena to be marked freed immediately")
+	}
+	if !called {
+		t.Error("expected OnFree callbacks to run before FreeAsync returns")
+	}
+	pendingFrees.Wait()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	_ = p.Get()
+}
+
+func TestFreeAsyncDoubleFree(t *testing.T) {
+	tests := []struct {
+		name   string
+		second func(a *Arena)
+	}{
+		{"FreeAsync", (*Arena).FreeAsync},
+		{"Free", (*Arena).Free},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			a.FreeAsync()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "double free") {
+					t.Errorf("expected double free panic, got %q", msg)
+				}
+			}()
+			tt.second(a)
+		})
+	}
+}
+
+func TestFreeAsyncQueueFull(t *testing.T) {
+	// More arenas than the queue holds must all be released
+	for i := 0; i < asyncFreeQueue*2; i++ {
+		a := New()
+		AllocSlice[byte](a, 1024)
+		a.FreeAsync()
+	}
+	pendingFrees.Wait()
+}
+
+func TestTryFree(t *testing.T) {
+	a := New()
+	p := Alloc(a, 1)
+
+	if !a.TryFree() {
+		t.Error("expected first TryFree to report true")
+	}
+	if !a.IsFreed() || p.Valid() {
+		t.Error("expected TryFree to free the arena")
+	}
+	if a.TryFree() {
+		t.Error("expected second TryFree to report false")
+	}
+
+	// Free after TryFree is still a double free
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "double free") {
+			t.Errorf("expected double free panic, got %q", msg)
+		}
+	}()
+	a.Free()
+}
@@ -0,0 +1,205 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// listNode is a List or Stack element stored in the arena
+type listNode[T any] struct {
+	value T
+	next  *listNode[T]
+}
+
+// List is a singly linked list whose nodes are allocated in the arena, so a
+// whole list (or graph of lists) is released at once by Free instead of being
+// traced by the garbage collector node by node.
+//
+// A List is not safe for concurrent use.
+// Every method panics if the arena has been freed or reset.
+type List[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the nodes belong to
+	head  *listNode[T]
+	tail  *listNode[T]
+	len   int
+}
+
+// NewList creates an empty List.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	l := safearena.NewList[int](a)
+//	l.PushBack(1)
+//	l.PushFront(0)
+//	l.Iterate(func(v int) bool { fmt.Println(v); return true })
+func NewList[T any](a *Arena) *List[T] {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return &List[T]{arena: a, gen: a.gen.Load()}
+}
+
+// PushFront inserts value at the front of the list
+func (l *List[T]) PushFront(value T) {
+	l.check()
+	n := newNode(l.arena, value)
+	n.next = l.head
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+	l.len++
+}
+
+// PushBack appends value to the back of the list
+func (l *List[T]) PushBack(value T) {
+	l.check()
+	n := newNode(l.arena, value)
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.next = n
+	}
+	l.tail = n
+	l.len++
+}
+
+// Len returns the number of elements in the list
+func (l *List[T]) Len() int {
+	l.check()
+	return l.len
+}
+
+// Iterate calls fn for each element from front to back until fn returns
+// false. The arena is checked before every step, so freeing it from fn (or
+// from another goroutine) panics on the next step instead of reading
+// freed nodes. fn must not modify the list.
+func (l *List[T]) Iterate(fn func(value T) bool) {
+	l.check()
+	for n := l.head; n != nil; n = n.next {
+		if !fn(n.value) {
+			return
+		}
+		l.check() // Before n.next, which fn may have freed
+	}
+}
+
+// check panics if the list's arena has been freed or reset
+func (l *List[T]) check() {
+	if l.arena.freed.Load() || !l.arena.live(l.gen) {
+		stack := captureStack(3)
+		panic(l.arena.staleError(l.gen, stack, nil))
+	}
+}
+
+// Stack is a LIFO stack whose nodes are allocated in the arena. Popped nodes
+// are kept on a free list and reused by later pushes, since arena memory
+// cannot be released individually.
+//
+// A Stack is not safe for concurrent use.
+// Every method panics if the arena has been freed or reset.
+type Stack[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the nodes belong to
+	top   *listNode[T]
+	free  *listNode[T] // Popped nodes available for reuse
+	len   int
+}
+
+// NewStack creates an empty Stack.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	s := safearena.NewStack[*Node](a)
+//	s.Push(root)
+//	for s.Len() > 0 {
+//	    n, _ := s.Pop()
+//	    // visit n, push its children...
+//	}
+func NewStack[T any](a *Arena) *Stack[T] {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return &Stack[T]{arena: a, gen: a.gen.Load()}
+}
+
+// Push adds value to the top of the stack
+func (s *Stack[T]) Push(value T) {
+	s.check()
+	n := s.free
+	if n != nil {
+		s.free = n.next
+		n.value = value
+	} else {
+		n = newNode(s.arena, value)
+	}
+	n.next = s.top
+	s.top = n
+	s.len++
+}
+
+// Pop removes and returns the value at the top of the stack.
+// It reports false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	s.check()
+	var zero T
+	n := s.top
+	if n == nil {
+		return zero, false
+	}
+	value := n.value
+	n.value = zero // Drop references held by the popped element
+	s.top = n.next
+	n.next = s.free
+	s.free = n
+	s.len--
+	return value, true
+}
+
+// Peek returns the value at the top of the stack without removing it.
+// It reports false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	s.check()
+	if s.top == nil {
+		var zero T
+		return zero, false
+	}
+	return s.top.value, true
+}
+
+// Len returns the number of elements on the stack
+func (s *Stack[T]) Len() int {
+	s.check()
+	return s.len
+}
+
+// check panics if the stack's arena has been freed or reset
+func (s *Stack[T]) check() {
+	if s.arena.freed.Load() || !s.arena.live(s.gen) {
+		stack := captureStack(3)
+		panic(s.arena.staleError(s.gen, stack, nil))
+	}
+}
+
+// newNode allocates a node holding value from the arena.
+// The caller must have checked that the arena is live.
+func newNode[T any](a *Arena, value T) *listNode[T] {
+	if !a.charge(int64(unsafe.Sizeof(listNode[T]{}))) {
+		stack := captureStack(3)
+		panic(a.chargeError(stack))
+	}
+
+	n := backingNew[listNode[T]](a.inner)
+	n.value = value
+	if a.debug != nil {
+		a.recordAlloc(unsafe.Pointer(n), captureStack(3), reflect.TypeFor[listNode[T]](), unsafe.Sizeof(*n))
+	}
+	return n
+}
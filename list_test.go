@@ -0,0 +1,137 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	const n = 10000
+	l := NewList[int](a)
+	for i := 1; i <= n; i++ {
+		l.PushBack(i)
+	}
+	l.PushFront(0)
+
+	if l.Len() != n+1 {
+		t.Errorf("expected %d elements, got %d", n+1, l.Len())
+	}
+
+	sum, first := 0, -1
+	l.Iterate(func(v int) bool {
+		if first < 0 {
+			first = v
+		}
+		sum += v
+		return true
+	})
+	if want := n * (n + 1) / 2; sum != want {
+		t.Errorf("expected sum %d, got %d", want, sum)
+	}
+	if first != 0 {
+		t.Errorf("expected PushFront value first, got %d", first)
+	}
+
+	count := 0
+	l.Iterate(func(int) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("expected iteration to stop after 3 elements, got %d", count)
+	}
+}
+
+func TestListFreeDuringIterate(t *testing.T) {
+	a := New()
+	l := NewList[string](a)
+	for _, s := range []string{"a", "b", "c"} {
+		l.PushBack(s)
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	l.Iterate(func(s string) bool {
+		if s == "b" {
+			a.Free()
+		}
+		return true
+	})
+	t.Error("expected Iterate to panic")
+}
+
+func TestStack(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := NewStack[int](a)
+	if _, ok := s.Pop(); ok {
+		t.Error("expected Pop on empty stack to fail")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Error("expected Peek on empty stack to fail")
+	}
+
+	for i := range 5 {
+		s.Push(i)
+	}
+	if v, ok := s.Peek(); !ok || v != 4 {
+		t.Errorf("expected Peek to return 4, got %d, %v", v, ok)
+	}
+	for want := 4; want >= 0; want-- {
+		if v, ok := s.Pop(); !ok || v != want {
+			t.Errorf("expected Pop to return %d, got %d, %v", want, v, ok)
+		}
+	}
+
+	// Popped nodes are reused without charging the arena again
+	bytes := a.Stats().Bytes
+	s.Push(7)
+	if a.Stats().Bytes != bytes {
+		t.Error("expected Push to reuse a popped node")
+	}
+	if v, _ := s.Pop(); v != 7 || s.Len() != 0 {
+		t.Errorf("expected to pop 7 and leave the stack empty, got %d with %d left", v, s.Len())
+	}
+}
+
+func TestListStackAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(a *Arena) func()
+	}{
+		{"list push", func(a *Arena) func() { l := NewList[int](a); return func() { l.PushBack(1) } }},
+		{"list len", func(a *Arena) func() { l := NewList[int](a); return func() { l.Len() } }},
+		{"list iterate", func(a *Arena) func() {
+			l := NewList[int](a)
+			return func() { l.Iterate(func(int) bool { return true }) }
+		}},
+		{"stack push", func(a *Arena) func() { s := NewStack[int](a); return func() { s.Push(1) } }},
+		{"stack pop", func(a *Arena) func() { s := NewStack[int](a); return func() { s.Pop() } }},
+		{"stack peek", func(a *Arena) func() { s := NewStack[int](a); return func() { s.Peek() } }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			use := tt.use(a)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			use()
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package safearena
+
+import (
+	"hash/maphash"
+	"unsafe"
+)
+
+// Slot states for ArenaMap entries
+const (
+	slotEmpty uint8 = iota
+	slotFull
+	slotDeleted // Tombstone left by Delete so probe chains stay intact
+)
+
+// mapEntry is a single ArenaMap slot
+type mapEntry[K comparable, V any] struct {
+	key   K
+	value V
+	state uint8
+}
+
+// ArenaMap is a hash map whose keys and values are stored by value in
+// arena-allocated slot arrays. It is a lifetime-checked alternative to a Go
+// map for request-scoped keyed data: the map carries its arena, so any access
+// after Free panics instead of reading released memory.
+//
+// ArenaMap uses open addressing with linear probing. When live entries plus
+// deletion tombstones would exceed 3/4 of the slots, the slot array is
+// doubled and rehashed into a new arena allocation. Arena memory cannot be
+// released individually, so outgrown arrays stay allocated until the arena is
+// freed or reset; size the map up front with NewMapSize when the entry count
+// is known.
+//
+// An ArenaMap is not safe for concurrent use.
+// Every method panics if the arena has been freed or reset.
+type ArenaMap[K comparable, V any] struct {
+	arena      *Arena
+	gen        uint64 // Arena generation the slots belong to
+	seed       maphash.Seed
+	slots      []mapEntry[K, V]
+	count      int // Live entries
+	tombstones int // Deleted slots
+}
+
+// minMapSlots is the slot count of a new ArenaMap
+const minMapSlots = 8
+
+// NewMap creates an empty ArenaMap.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	headers := safearena.NewMap[string, string](a)
+//	headers.Set("Content-Type", "application/json")
+//	ct, ok := headers.Get("Content-Type")
+func NewMap[K comparable, V any](a *Arena) *ArenaMap[K, V] {
+	return NewMapSize[K, V](a, 0)
+}
+
+// NewMapSize creates an empty ArenaMap with room for at least size entries
+// before it needs to grow.
+//
+// Panics if the arena has already been freed.
+func NewMapSize[K comparable, V any](a *Arena, size int) *ArenaMap[K, V] {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	n := minMapSlots
+	for n*3/4 < size {
+		n *= 2
+	}
+
+	m := &ArenaMap[K, V]{
+		arena: a,
+		gen:   a.gen.Load(),
+		seed:  maphash.MakeSeed(),
+	}
+	m.slots = m.makeSlots(n)
+	return m
+}
+
+// Get returns the value stored under key, and whether it was found
+func (m *ArenaMap[K, V]) Get(key K) (V, bool) {
+	m.check()
+	if i, ok := m.find(key); ok {
+		return m.slots[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores value under key, replacing any existing value
+func (m *ArenaMap[K, V]) Set(key K, value V) {
+	m.check()
+	if i, ok := m.find(key); ok {
+		m.slots[i].value = value
+		return
+	}
+
+	if (m.count+m.tombstones+1)*4 > len(m.slots)*3 {
+		m.rehash()
+	}
+	m.insert(key, value)
+	m.count++
+}
+
+// Delete removes key from the map and reports whether it was present
+func (m *ArenaMap[K, V]) Delete(key K) bool {
+	m.check()
+	i, ok := m.find(key)
+	if !ok {
+		return false
+	}
+	m.slots[i] = mapEntry[K, V]{state: slotDeleted}
+	m.count--
+	m.tombstones++
+	return true
+}
+
+// Len returns the number of entries in the map
+func (m *ArenaMap[K, V]) Len() int {
+	m.check()
+	return m.count
+}
+
+// Range calls fn for each entry until fn returns false.
+// The iteration order is unspecified. The arena is checked after every call,
+// so freeing it from fn panics instead of reading freed slots.
+// fn must not modify the map.
+func (m *ArenaMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.check()
+	for i := range m.slots {
+		if m.slots[i].state != slotFull {
+			continue
+		}
+		if !fn(m.slots[i].key, m.slots[i].value) {
+			return
+		}
+		m.check() // fn may have freed the slots
+	}
+}
+
+// find returns the slot index holding key
+func (m *ArenaMap[K, V]) find(key K) (int, bool) {
+	mask := uint64(len(m.slots) - 1)
+	for i := maphash.Comparable(m.seed, key) & mask; ; i = (i + 1) & mask {
+		switch slot := &m.slots[i]; slot.state {
+		case slotEmpty:
+			return 0, false
+		case slotFull:
+			if slot.key == key {
+				return int(i), true
+			}
+		}
+	}
+}
+
+// insert stores a key known to be absent in the first free slot of its
+// probe chain
+func (m *ArenaMap[K, V]) insert(key K, value V) {
+	mask := uint64(len(m.slots) - 1)
+	for i := maphash.Comparable(m.seed, key) & mask; ; i = (i + 1) & mask {
+		if slot := &m.slots[i]; slot.state != slotFull {
+			if slot.state == slotDeleted {
+				m.tombstones--
+			}
+			*slot = mapEntry[K, V]{key: key, value: value, state: slotFull}
+			return
+		}
+	}
+}
+
+// rehash moves all entries into a new slot array, doubling it unless most of
+// the load is tombstones
+func (m *ArenaMap[K, V]) rehash() {
+	n := len(m.slots)
+	if (m.count+1)*2 > n {
+		n *= 2
+	}
+
+	old := m.slots
+	m.slots = m.makeSlots(n)
+	m.tombstones = 0
+	for i := range old {
+		if old[i].state == slotFull {
+			m.insert(old[i].key, old[i].value)
+		}
+	}
+}
+
+// makeSlots allocates an empty slot array of n slots from the arena
+func (m *ArenaMap[K, V]) makeSlots(n int) []mapEntry[K, V] {
+	a := m.arena
+	if !a.charge(int64(n) * int64(unsafe.Sizeof(mapEntry[K, V]{}))) {
+		stack := captureStack(3)
+		panic(a.chargeError(stack))
+	}
+	return backingMakeSlice[mapEntry[K, V]](a.inner, n, n)
+}
+
+// check panics if the map's arena has been freed or reset
+func (m *ArenaMap[K, V]) check() {
+	if m.arena.freed.Load() || !m.arena.live(m.gen) {
+		stack := captureStack(3)
+		panic(m.arena.staleError(m.gen, stack, nil))
+	}
+}
@@ -0,0 +1,150 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestArenaMap(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	m := NewMap[string, int](a)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 3) // Replace
+
+	if m.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", m.Len())
+	}
+	if v, ok := m.Get("a"); !ok || v != 3 {
+		t.Errorf("expected a=3, got %d (ok=%v)", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("expected missing key not to be found")
+	}
+
+	if !m.Delete("a") {
+		t.Error("expected delete of present key to succeed")
+	}
+	if m.Delete("a") {
+		t.Error("expected delete of absent key to fail")
+	}
+	if _, ok := m.Get("a"); ok {
+		t.Error("expected deleted key not to be found")
+	}
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("expected b=2 after delete, got %d (ok=%v)", v, ok)
+	}
+}
+
+func TestArenaMapGrowth(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	const n = 1000
+	m := NewMap[int, string](a)
+	for i := 0; i < n; i++ {
+		m.Set(i, fmt.Sprint(i))
+	}
+	// Churn through deletes to exercise tombstone reuse and rehashing
+	for i := 0; i < n; i += 2 {
+		m.Delete(i)
+	}
+	for i := n; i < 2*n; i++ {
+		m.Set(i, fmt.Sprint(i))
+	}
+
+	if want := n/2 + n; m.Len() != want {
+		t.Errorf("expected %d entries, got %d", want, m.Len())
+	}
+	for i := 0; i < 2*n; i++ {
+		v, ok := m.Get(i)
+		wantOK := i >= n || i%2 == 1
+		if ok != wantOK || (ok && v != fmt.Sprint(i)) {
+			t.Fatalf("key %d: got %q (ok=%v), want present=%v", i, v, ok, wantOK)
+		}
+	}
+}
+
+func TestArenaMapRange(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	m := NewMapSize[int, int](a, 100)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i*i)
+	}
+
+	sum := 0
+	m.Range(func(k, v int) bool {
+		if v != k*k {
+			t.Errorf("expected %d=%d, got %d", k, k*k, v)
+		}
+		sum += k
+		return true
+	})
+	if sum != 45 {
+		t.Errorf("expected key sum 45, got %d", sum)
+	}
+
+	visited := 0
+	m.Range(func(k, v int) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("expected Range to stop after 1 entry, visited %d", visited)
+	}
+}
+
+func TestArenaMapFreeDuringRange(t *testing.T) {
+	a := New()
+	m := NewMap[int, int](a)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i)
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	m.Range(func(k, v int) bool {
+		a.Free()
+		return true
+	})
+	t.Error("expected Range to panic")
+}
+
+func TestArenaMapAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(m *ArenaMap[string, int])
+	}{
+		{"get", func(m *ArenaMap[string, int]) { m.Get("a") }},
+		{"set", func(m *ArenaMap[string, int]) { m.Set("a", 1) }},
+		{"delete", func(m *ArenaMap[string, int]) { m.Delete("a") }},
+		{"len", func(m *ArenaMap[string, int]) { m.Len() }},
+		{"range", func(m *ArenaMap[string, int]) { m.Range(func(string, int) bool { return true }) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			m := NewMap[string, int](a)
+			m.Set("a", 1)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use(m)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package safearena
+
+// Mark is a checkpoint in an arena's allocation history, taken with
+// Arena.Mark and rolled back to with Arena.Release.
+type Mark struct {
+	arena *Arena
+	gen   uint64 // First generation allocated after the mark
+}
+
+// genRange is an inclusive range of released generations
+type genRange struct {
+	from, to uint64
+}
+
+// Mark returns a checkpoint for phase-based processing within a long-lived
+// arena. Release(mark) later invalidates everything allocated after the mark,
+// while values allocated before it stay valid. Marks nest: release inner
+// marks before outer ones.
+//
+// Mark must not be called concurrently with Reset or Release.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	for _, phase := range phases {
+//	    mark := a.Mark()
+//	    scratch := safearena.AllocSlice[byte](a, phase.Size)
+//	    phase.Run(scratch.Get())
+//	    a.Release(mark) // scratch is now invalid
+//	}
+func (a *Arena) Mark() Mark {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "Mark after free", stack, AllocAfterFree))
+	}
+	return Mark{arena: a, gen: a.gen.Add(1)}
+}
+
+// Release invalidates every Ptr and Slice allocated since mark was taken:
+// accessing them panics with "use after release". Values allocated before
+// the mark are unaffected, and containers (NewWriter, NewMap, ...) created
+// before the mark remain usable.
+//
+// Go arenas cannot free part of their memory, so Release does not reclaim
+// anything: the released memory stays allocated, and counted in Stats, until
+// the arena is freed or reset. Release provides scoped lifetimes, not reuse.
+//
+// Release must not be called concurrently with Mark or Reset.
+//
+// Panics if the arena has been freed, if mark was taken from a different
+// arena, or if the arena has been reset since mark was taken.
+func (a *Arena) Release(mark Mark) {
+	if mark.arena != a {
+		panic("safearena: Release of a mark from a different arena")
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "release after free", stack, AllocAfterFree))
+	}
+	if mark.gen < a.floor.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "release of a mark from before reset", stack, UseAfterReset))
+	}
+
+	var ranges []genRange
+	if old := a.released.Load(); old != nil {
+		ranges = append(ranges, *old...) // Copy: readers may hold the old slice
+	}
+	ranges = append(ranges, genRange{from: mark.gen, to: a.gen.Load()})
+	a.released.Store(&ranges)
+	a.gen.Add(1) // Allocations after Release must not fall in the range
+}
+
+// live reports whether values allocated in generation gen are still valid.
+// Values from the current generation always are; older ones need liveSlow.
+func (a *Arena) live(gen uint64) bool {
+	return gen == a.gen.Load() || a.liveSlow(gen)
+}
+
+// liveSlow reports whether generation gen has survived every Reset and
+// Release since it was current
+func (a *Arena) liveSlow(gen uint64) bool {
+	if gen < a.floor.Load() {
+		return false
+	}
+	if ranges := a.released.Load(); ranges != nil {
+		for _, r := range *ranges {
+			if gen >= r.from && gen <= r.to {
+				return false
+			}
+		}
+	}
+	return true
+}
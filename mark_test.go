@@ -0,0 +1,127 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMarkRelease(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	before := Alloc(a, 1)
+	mark := a.Mark()
+	after := Alloc(a, 2)
+	scratch := AllocSlice[byte](a, 16)
+
+	a.Release(mark)
+
+	if !before.Valid() || before.Deref() != 1 {
+		t.Error("expected value allocated before the mark to survive Release")
+	}
+	if after.Valid() || scratch.Valid() {
+		t.Error("expected values allocated after the mark to be invalid")
+	}
+	if next := Alloc(a, 3); !next.Valid() || next.Deref() != 3 {
+		t.Error("expected allocation after Release to be valid")
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after release") {
+			t.Errorf("expected use after release panic, got %q", msg)
+		}
+	}()
+	_ = after.Get()
+}
+
+func TestMarkNested(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	outer := a.Mark()
+	p1 := Alloc(a, 1)
+	inner := a.Mark()
+	p2 := Alloc(a, 2)
+
+	a.Release(inner)
+	if !p1.Valid() || p2.Valid() {
+		t.Errorf("after inner release: expected p1 valid and p2 invalid, got %v and %v", p1.Valid(), p2.Valid())
+	}
+
+	p3 := Alloc(a, 3)
+	a.Release(outer)
+	if p1.Valid() || p3.Valid() {
+		t.Error("expected outer release to invalidate everything after the outer mark")
+	}
+}
+
+func TestReleaseAfterReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	mark := a.Mark()
+	a.Reset()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic releasing a mark from before reset")
+		}
+	}()
+	a.Release(mark)
+}
+
+func TestReleaseForeignMark(t *testing.T) {
+	a, b := New(), New()
+	defer a.Free()
+	defer b.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "different arena") {
+			t.Errorf("expected foreign mark panic, got %q", msg)
+		}
+	}()
+	b.Release(a.Mark())
+}
+
+func TestResetAfterRelease(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	before := Alloc(a, 1)
+	mark := a.Mark()
+	Alloc(a, 2)
+	a.Release(mark)
+	a.Reset()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after reset") {
+			t.Errorf("expected use after reset panic, got %q", msg)
+		}
+	}()
+	_ = before.Get()
+}
+
+func TestReleaseBlobStore(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	store := NewBlobStore(a)
+	_, kept := store.Put([]byte("kept"))
+	mark := a.Mark()
+	_, dropped := store.Put([]byte("dropped"))
+	a.Release(mark)
+
+	if _, ok := store.Get(kept); !ok {
+		t.Error("expected blob stored before the mark to remain")
+	}
+	if _, ok := store.Get(dropped); ok {
+		t.Error("expected blob stored after the mark to be dropped")
+	}
+	if store.Len() != 1 {
+		t.Errorf("expected 1 blob, got %d", store.Len())
+	}
+}
@@ -0,0 +1,51 @@
+package safearena
+
+// Merge moves the values of ptrs from src into dst and frees src, returning
+// Ptr values bound to dst in the same order. It consolidates many short-lived
+// arenas into one longer-lived one at a pipeline boundary. Go arenas cannot
+// hand memory to each other, so this is a copy followed by a Free, not a
+// transfer of pages: only the values passed in survive.
+//
+// The copies are shallow, as with Clone. A value that points into src (a
+// string, slice, or pointer allocated there) would dangle once src is freed;
+// move those values with deep copies instead. The values are placed in one
+// contiguous block in dst. To move values of several types, copy all but the
+// last type with Alloc(dst, p.Deref()) first, then Merge the last.
+//
+// Every Ptr is checked before anything is copied, as with CloneAll.
+//
+// Panics if dst and src are the same arena, if dst has been freed, if any Ptr
+// is not from src, or if src has been freed or reset since any of ptrs was
+// allocated.
+//
+// Example:
+//
+//	for batch := range batches {
+//	    results := decode(batch.arena, batch.data) // []Ptr[Record] in batch.arena
+//	    kept = append(kept, safearena.Merge(long, batch.arena, results...)...)
+//	}
+func Merge[T any](dst, src *Arena, ptrs ...Ptr[T]) []Ptr[T] {
+	if dst == src {
+		panic("safearena: Merge of an arena into itself")
+	}
+	if dst.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(dst, "allocation after free", stack, AllocAfterFree))
+	}
+	for _, p := range ptrs {
+		if p.arena != src {
+			panic("safearena: Merge of a Ptr from a different arena than src")
+		}
+	}
+	checkCloneAll("Merge", ptrs)
+
+	values := makeSlice[T](dst, len(ptrs), len(ptrs))
+	gen := dst.gen.Load()
+	out := make([]Ptr[T], len(ptrs))
+	for i, p := range ptrs {
+		values[i] = *p.ptr
+		out[i] = Ptr[T]{ptr: &values[i], arena: dst, gen: gen}
+	}
+	src.Free()
+	return out
+}
@@ -0,0 +1,89 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	type record struct {
+		ID    int
+		Score float64
+	}
+
+	dst := New()
+	defer dst.Free()
+
+	var kept []Ptr[record]
+	var sources []*Arena
+	for batch := 0; batch < 2; batch++ {
+		src := New()
+		sources = append(sources, src)
+		var ptrs []Ptr[record]
+		for i := 0; i < 3; i++ {
+			ptrs = append(ptrs, Alloc(src, record{ID: 10*batch + i, Score: float64(i)}))
+		}
+		kept = append(kept, Merge(dst, src, ptrs...)...)
+	}
+
+	for _, src := range sources {
+		if !src.IsFreed() {
+			t.Error("expected Merge to free src")
+		}
+	}
+	var ids []int
+	for _, p := range kept {
+		ids = append(ids, p.Get().ID)
+	}
+	if fmt.Sprint(ids) != "[0 1 2 10 11 12]" {
+		t.Errorf("unexpected merged values %v", ids)
+	}
+	if stats := dst.Stats(); stats.Allocations != 2 {
+		t.Errorf("expected one dst allocation per merge, got %+v", stats)
+	}
+}
+
+func TestMergeMisuse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		merge func()
+		want  string
+	}{
+		"into itself": {func() {
+			a := New()
+			defer a.Free()
+			Merge(a, a, Alloc(a, 1))
+		}, "Merge of an arena into itself"},
+		"foreign ptr": {func() {
+			dst, src, other := New(), New(), New()
+			defer dst.Free()
+			defer src.Free()
+			defer other.Free()
+			Merge(dst, src, Alloc(src, 1), Alloc(other, 2))
+		}, "different arena than src"},
+		"freed dst": {func() {
+			dst, src := New(), New()
+			defer src.Free()
+			dst.Free()
+			Merge(dst, src, Alloc(src, 1))
+		}, "allocation after free"},
+		"reset src": {func() {
+			dst, src := New(), New()
+			defer dst.Free()
+			defer src.Free()
+			p := Alloc(src, 1)
+			src.Reset()
+			Merge(dst, src, p, Alloc(src, 2))
+		}, "Merge() called with 1 of 2 values stale (first at index 0)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.merge()
+		})
+	}
+}
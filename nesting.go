@@ -0,0 +1,74 @@
+package safearena
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// scopeWarning prints the warning for an allocation into an outer scope's
+// arena. Tests replace it to capture the message.
+var scopeWarning = func(msg string) {
+	fmt.Print(msg)
+}
+
+// scopeStacks maps a goroutine id to the debug-mode arenas of the Scoped
+// calls (and WithArena calls) active on that goroutine, innermost last
+var scopeStacks sync.Map // int64 -> *[]*Arena
+
+// enterScope pushes a onto the current goroutine's scope stack and returns
+// the function that pops it. Scoped and friends call it for debug-mode
+// arenas, so allocations can be checked against the innermost scope:
+//
+//	if a.debug != nil {
+//	    defer enterScope(a)()
+//	}
+func enterScope(a *Arena) func() {
+	id := goid()
+	v, _ := scopeStacks.LoadOrStore(id, new([]*Arena))
+	stack := v.(*[]*Arena)
+	*stack = append(*stack, a)
+	return func() {
+		*stack = (*stack)[:len(*stack)-1]
+		if len(*stack) == 0 {
+			scopeStacks.Delete(id)
+		}
+	}
+}
+
+// checkScope warns if a is the arena of an enclosing scope rather than the
+// innermost one on the current goroutine. Allocating into an outer arena from
+// an inner scope is legal, but it usually means the wrong arena variable was
+// captured, and the value then outlives the scope it was built for.
+// site is where the allocation was made.
+func (a *Arena) checkScope(site *stackInfo) {
+	v, ok := scopeStacks.Load(goid())
+	if !ok {
+		return
+	}
+	stack := *v.(*[]*Arena)
+	inner := stack[len(stack)-1]
+	if inner == a {
+		return
+	}
+	for _, outer := range stack[:len(stack)-1] {
+		if outer == a {
+			scopeWarning(fmt.Sprintf("WARNING: allocation at %s uses %s from an outer scope; the innermost scope's arena is %s\n",
+				siteString(site), arenaLabel(a.id, a.name), arenaLabel(inner.id, inner.name)))
+			return
+		}
+	}
+}
+
+// goid returns the current goroutine's id, parsed from its stack header
+// ("goroutine 42 [running]:"). It is slow and meant for debug mode only.
+func goid() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b = b[:bytes.IndexByte(b, ' ')]
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,76 @@
+package safearena
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func captureScopeWarnings(t *testing.T) *[]string {
+	t.Helper()
+	var warnings []string
+	old := scopeWarning
+	scopeWarning = func(msg string) { warnings = append(warnings, msg) }
+	t.Cleanup(func() { scopeWarning = old })
+	return &warnings
+}
+
+func TestScopeNestingGuard(t *testing.T) {
+	enableDebug(t)
+	warnings := captureScopeWarnings(t)
+
+	ScopedPtr(func(outer *Arena) {
+		Alloc(outer, 1) // Innermost scope: fine
+		ScopedPtr(func(inner *Arena) {
+			Alloc(inner, 2)
+			AllocSlice[int](outer, 4) // Captured the wrong arena
+			if len(*warnings) != 1 {
+				t.Fatalf("expected 1 warning, got %q", *warnings)
+			}
+		})
+		WithArena(outer, func(a *Arena) int { return Alloc(a, 3).Deref() })
+	})
+
+	if len(*warnings) != 1 {
+		t.Fatalf("expected only the cross-scope allocation to warn, got %q", *warnings)
+	}
+	if w := (*warnings)[0]; !strings.Contains(w, "nesting_test.go") || !strings.Contains(w, "outer scope") {
+		t.Errorf("expected warning naming the allocation site, got %q", w)
+	}
+	if _, ok := scopeStacks.Load(goid()); ok {
+		t.Error("expected the scope stack to be removed after the outermost scope")
+	}
+}
+
+func TestScopeNestingGuardGoroutines(t *testing.T) {
+	enableDebug(t)
+	warnings := captureScopeWarnings(t)
+
+	ScopedPtr(func(outer *Arena) {
+		ScopedPtr(func(inner *Arena) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ScopedPtr(func(own *Arena) { Alloc(own, 1) })
+			}()
+			wg.Wait()
+		})
+	})
+	if len(*warnings) != 0 {
+		t.Errorf("expected scopes of other goroutines to be independent, got %q", *warnings)
+	}
+}
+
+func TestScopeNestingGuardOff(t *testing.T) {
+	warnings := captureScopeWarnings(t)
+
+	ScopedPtr(func(outer *Arena) {
+		ScopedPtr(func(inner *Arena) {
+			Alloc(outer, 1)
+		})
+	})
+	if len(*warnings) != 0 {
+		t.Errorf("expected no warnings outside debug mode, got %q", *warnings)
+	}
+}
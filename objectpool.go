@@ -0,0 +1,121 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// ObjectPool recycles objects of one type within an arena's lifetime, for
+// allocation-heavy algorithms that repeatedly build and discard values, such
+// as graph nodes rebuilt on every pass. Arena memory cannot be released
+// individually, so without a pool each discarded object stays allocated
+// until the arena is freed; with one, released objects are handed out again.
+//
+// The pool's objects and its free list both live in the arena. Acquire
+// returns a zeroed object, whether new or recycled.
+//
+// An ObjectPool is not safe for concurrent use.
+// Every method panics if the arena has been freed or reset.
+//
+// Example:
+//
+//	pool := safearena.NewObjectPool[Node](a, 1024)
+//	for range passes {
+//	    n := pool.Acquire()
+//	    // ... use n.Get() ...
+//	    pool.Release(n)
+//	}
+type ObjectPool[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the objects belong to
+	free  []*T   // Arena-backed; released and preallocated objects
+}
+
+// NewObjectPool creates a pool with prealloc objects allocated up front in
+// one contiguous block.
+//
+// Panics if prealloc is negative or if the arena has already been freed.
+func NewObjectPool[T any](a *Arena, prealloc int) *ObjectPool[T] {
+	if prealloc < 0 {
+		panic("safearena: negative ObjectPool prealloc")
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	p := &ObjectPool[T]{arena: a, gen: a.gen.Load()}
+	if prealloc > 0 {
+		objects := makeSlice[T](a, prealloc, prealloc)
+		p.free = makeSlice[*T](a, prealloc, prealloc)
+		for i := range objects {
+			p.free[i] = &objects[prealloc-1-i] // Hand out in address order
+		}
+	}
+	return p
+}
+
+// Acquire returns a zeroed object from the pool, allocating a new one in the
+// arena if none is free
+func (p *ObjectPool[T]) Acquire() Ptr[T] {
+	p.check()
+	var obj *T
+	if n := len(p.free); n > 0 {
+		obj = p.free[n-1]
+		p.free = p.free[:n-1]
+		var zero T
+		*obj = zero
+	} else {
+		obj = newObject[T](p.arena)
+	}
+	return Ptr[T]{ptr: obj, arena: p.arena, gen: p.gen}
+}
+
+// Release returns obj to the pool for reuse by a later Acquire. obj must have
+// come from this pool and must not be used, or released again, afterwards.
+//
+// Panics if obj is from a different arena.
+func (p *ObjectPool[T]) Release(obj Ptr[T]) {
+	p.check()
+	if obj.arena != p.arena || obj.gen != p.gen {
+		panic("safearena: ObjectPool.Release of an object from a different arena")
+	}
+
+	n := len(p.free)
+	if n == cap(p.free) {
+		grown := makeSlice[*T](p.arena, n, max(2*n, 4))
+		copy(grown, p.free)
+		p.free = grown
+	}
+	p.free = append(p.free, obj.ptr) // Fits in capacity; stays in the arena
+}
+
+// Idle returns the number of objects available to Acquire without
+// allocating
+func (p *ObjectPool[T]) Idle() int {
+	p.check()
+	return len(p.free)
+}
+
+// check panics if the pool's arena has been freed or reset
+func (p *ObjectPool[T]) check() {
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		stack := captureStack(3)
+		panic(p.arena.staleError(p.gen, stack, nil))
+	}
+}
+
+// newObject allocates a zeroed T from the arena.
+// The caller must have checked that the arena is live.
+func newObject[T any](a *Arena) *T {
+	if !a.charge(int64(unsafe.Sizeof(*new(T)))) {
+		stack := captureStack(3)
+		panic(a.chargeError(stack))
+	}
+
+	obj := backingNew[T](a.inner)
+	if a.debug != nil {
+		a.recordAlloc(unsafe.Pointer(obj), captureStack(3), reflect.TypeFor[T](), unsafe.Sizeof(*obj))
+	}
+	return obj
+}
@@ -0,0 +1,108 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type poolNode struct {
+	ID    int
+	Edges []int
+}
+
+func TestObjectPool(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	pool := NewObjectPool[poolNode](a, 2)
+	if pool.Idle() != 2 {
+		t.Fatalf("expected 2 preallocated objects, got %d", pool.Idle())
+	}
+	allocs := a.Stats().Allocations
+
+	first := pool.Acquire()
+	first.Get().ID = 1
+	first.Get().Edges = []int{2, 3}
+	pool.Release(first)
+
+	again := pool.Acquire()
+	if again.Get() != first.Get() {
+		t.Error("expected the released object to be reused")
+	}
+	if n := again.Deref(); n.ID != 0 || n.Edges != nil {
+		t.Errorf("expected a zeroed object on reacquire, got %+v", n)
+	}
+
+	// Exhaust the preallocated objects, then grow
+	second := pool.Acquire()
+	third := pool.Acquire()
+	if pool.Idle() != 0 {
+		t.Errorf("expected no idle objects, got %d", pool.Idle())
+	}
+	if a.Stats().Allocations != allocs+1 {
+		t.Errorf("expected exactly 1 new allocation, got %d", a.Stats().Allocations-allocs)
+	}
+	pool.Release(again)
+	pool.Release(second)
+	pool.Release(third)
+	if pool.Idle() != 3 {
+		t.Errorf("expected 3 idle objects, got %d", pool.Idle())
+	}
+}
+
+func TestObjectPoolCycles(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	pool := NewObjectPool[poolNode](a, 64)
+	allocs := a.Stats().Allocations
+	for cycle := 0; cycle < 100; cycle++ {
+		nodes := make([]Ptr[poolNode], 64)
+		for i := range nodes {
+			nodes[i] = pool.Acquire()
+			nodes[i].Get().ID = cycle
+		}
+		for _, n := range nodes {
+			pool.Release(n)
+		}
+	}
+	if grown := a.Stats().Allocations - allocs; grown != 0 {
+		t.Errorf("expected steady-state cycles to allocate nothing, got %d allocations", grown)
+	}
+}
+
+func TestObjectPoolAfterFree(t *testing.T) {
+	a := New()
+	pool := NewObjectPool[int](a, 1)
+	obj := pool.Acquire()
+	other := New()
+	defer other.Free()
+
+	func() {
+		defer func() {
+			msg := fmt.Sprint(recover())
+			if !strings.Contains(msg, "different arena") {
+				t.Errorf("expected different arena panic, got %q", msg)
+			}
+		}()
+		pool.Release(Alloc(other, 1))
+	}()
+
+	a.Free()
+	for name, op := range map[string]func(){
+		"Acquire": func() { pool.Acquire() },
+		"Release": func() { pool.Release(obj) },
+		"object":  func() { obj.Get() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			op()
+		})
+	}
+}
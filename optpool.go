@@ -0,0 +1,78 @@
+package safearena
+
+import "fmt"
+
+// OptPool recycles ArenaOpt handles between units of work. Put resets an
+// arena (see ArenaOpt.Reset) before keeping it, so PtrOpt and SliceOpt values
+// from the previous user panic with "use after reset" instead of reading
+// memory handed to the next one.
+//
+// Pooling does not make arena memory cheaper: Go arenas cannot be reused
+// after their memory is released, so every Put frees the arena's memory and
+// maps a fresh arena, the same work as Free followed by NewOpt. What the pool
+// saves is the ArenaOpt itself, and it bounds how many idle arenas exist.
+//
+// The pool keeps at most size idle arenas; Put frees any beyond that, so
+// idle memory stays bounded and pooled arenas are always released explicitly
+// rather than left to the garbage collector.
+//
+// An OptPool is safe for concurrent use, but each arena must be used by one
+// goroutine at a time between Get and Put.
+//
+// Example:
+//
+//	pool := safearena.NewOptPool(runtime.GOMAXPROCS(0))
+//	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//	    a := pool.Get()
+//	    defer pool.Put(a)
+//	    handle(a, w, r)
+//	})
+type OptPool struct {
+	idle chan *ArenaOpt
+}
+
+// NewOptPool creates a pool that keeps at most size idle arenas.
+//
+// Panics if size is negative.
+func NewOptPool(size int) *OptPool {
+	if size < 0 {
+		panic(fmt.Sprintf("safearena: negative pool size %d", size))
+	}
+	return &OptPool{idle: make(chan *ArenaOpt, size)}
+}
+
+// Get returns an idle arena from the pool, or a new one if the pool is empty
+func (p *OptPool) Get() *ArenaOpt {
+	select {
+	case a := <-p.idle:
+		return a
+	default:
+		return NewOpt()
+	}
+}
+
+// Put resets a and returns it to the pool, or frees it if the pool is full.
+// a must not be used after Put.
+//
+// Panics if a has already been freed.
+func (p *OptPool) Put(a *ArenaOpt) {
+	a.Reset()
+	select {
+	case p.idle <- a:
+	default:
+		a.Free()
+	}
+}
+
+// Close frees all idle arenas. Arenas returned by Put after Close are pooled
+// again, so stop using the pool before closing it.
+func (p *OptPool) Close() {
+	for {
+		select {
+		case a := <-p.idle:
+			a.Free()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestArenaOptReset(t *testing.T) {
+	a := NewOptWithStats()
+	defer a.Free()
+
+	p := AllocOpt(a, 1)
+	s := AllocSliceOpt[int](a, 4)
+	a.Reset()
+
+	if stats := a.Stats(); stats.Allocations != 0 || stats.Bytes != 0 || stats.HighWater == 0 {
+		t.Errorf("expected zeroed stats with HighWater kept, got %+v", stats)
+	}
+	fresh := AllocOpt(a, 2)
+	if fresh.Deref() != 2 {
+		t.Error("expected arena to be usable after Reset")
+	}
+
+	for name, access := range map[string]func(){
+		"PtrOpt":   func() { p.Get() },
+		"SliceOpt": func() { s.Get() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after reset") {
+					t.Errorf("expected use after reset panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func TestArenaOptResetAfterFree(t *testing.T) {
+	a := NewOpt()
+	p := AllocOpt(a, 1)
+	a.Free()
+
+	func() {
+		defer func() {
+			msg := fmt.Sprint(recover())
+			if !strings.Contains(msg, "use after free") {
+				t.Errorf("expected use after free panic, got %q", msg)
+			}
+		}()
+		p.Get()
+	}()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "reset after free") {
+			t.Errorf("expected reset after free panic, got %q", msg)
+		}
+	}()
+	a.Reset()
+}
+
+func TestOptPool(t *testing.T) {
+	pool := NewOptPool(1)
+	defer pool.Close()
+
+	a := pool.Get()
+	stale := AllocOpt(a, 1)
+	pool.Put(a)
+
+	if pool.Get() != a {
+		t.Error("expected Get to reuse the idle arena")
+	}
+	func() {
+		defer func() {
+			msg := fmt.Sprint(recover())
+			if !strings.Contains(msg, "use after reset") {
+				t.Errorf("expected use after reset panic, got %q", msg)
+			}
+		}()
+		stale.Get()
+	}()
+
+	b := pool.Get()
+	pool.Put(a)
+	pool.Put(b) // Pool is full
+	if !b.IsFreed() {
+		t.Error("expected Put to free an arena beyond the pool size")
+	}
+}
+
+func TestOptPoolConcurrentReuse(t *testing.T) {
+	pool := NewOptPool(4)
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				a := pool.Get()
+				p := AllocOpt(a, g*1000+i)
+				s := AllocSliceOpt[int](a, 8)
+				s.Get()[7] = i
+				if p.Deref() != g*1000+i || s.Get()[7] != i {
+					t.Errorf("goroutine %d: corrupted values in pooled arena", g)
+				}
+				pool.Put(a)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
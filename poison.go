@@ -0,0 +1,59 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// poisonPattern is written over freed debug-mode allocations
+var poisonPattern = [2]byte{0xDE, 0xAD}
+
+// poison overwrites every allocation made in generations from through to.
+// Pointer-free memory gets poisonPattern; memory that holds pointers is
+// zeroed with typed writes, because garbage pointers would crash the GC.
+func (d *debugState) poison(from, to uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, rec := range d.allocs {
+		if rec.gen < from || rec.gen > to || rec.size == 0 {
+			continue
+		}
+
+		elem, n := rec.typ, uintptr(1)
+		if rec.slice {
+			elem = rec.typ.Elem()
+			if elem.Size() > 0 {
+				n = rec.size / elem.Size()
+			}
+		}
+
+		if hasPointers(elem) {
+			reflect.NewAt(reflect.ArrayOf(int(n), elem), rec.ptr).Elem().SetZero()
+			continue
+		}
+		mem := unsafe.Slice((*byte)(rec.ptr), rec.size)
+		for i := range mem {
+			mem[i] = poisonPattern[i%len(poisonPattern)]
+		}
+	}
+}
+
+// hasPointers reports whether values of type t contain pointers the garbage
+// collector scans
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Map, reflect.Chan,
+		reflect.Func, reflect.Interface, reflect.Slice, reflect.String:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
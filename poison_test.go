@@ -0,0 +1,97 @@
+package safearena
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPoisonOnFree(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	s := AllocSlice[byte](a, 5)
+	copy(s.Get(), "hello")
+	raw := s.Get() // Retained past Free, as UnsafeGet-style misuse would
+
+	a.Free()
+
+	want := []byte{0xDE, 0xAD, 0xDE, 0xAD, 0xDE}
+	if string(raw) != string(want) {
+		t.Errorf("expected poisoned bytes % x, got % x", want, raw)
+	}
+}
+
+func TestPoisonZeroesPointers(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	x := 42
+	s := AllocSlice[*int](a, 2)
+	s.Get()[0] = &x
+	raw := s.Get()
+
+	a.Free()
+
+	if raw[0] != nil {
+		t.Error("expected pointer element to be zeroed")
+	}
+}
+
+func TestPoisonOnReset(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	old := AllocSlice[uint16](a, 1)
+	raw := old.Get()
+	raw[0] = 1
+
+	a.Reset()
+	if raw[0] != 0xADDE && raw[0] != 0xDEAD {
+		t.Errorf("expected poisoned value, got %#x", raw[0])
+	}
+
+	// Allocations from the new generation are not poisoned by the old one
+	fresh := AllocSlice[uint16](a, 1)
+	fresh.Get()[0] = 7
+	if got := fresh.Get()[0]; got != 7 {
+		t.Errorf("expected 7, got %d", got)
+	}
+}
+
+func TestNoPoisonWithoutDebug(t *testing.T) {
+	a := New()
+	s := AllocSlice[byte](a, 2)
+	copy(s.Get(), "ok")
+	raw := s.Get()
+
+	a.Free()
+
+	if string(raw) != "ok" {
+		t.Errorf("expected memory untouched outside debug mode, got %q", raw)
+	}
+}
+
+func TestHasPointers(t *testing.T) {
+	tests := []struct {
+		typ  any
+		want bool
+	}{
+		{int64(0), false},
+		{[4]byte{}, false},
+		{struct{ A, B int }{}, false},
+		{"", true},
+		{[]int(nil), true},
+		{struct{ P *int }{}, true},
+		{[2]map[int]int{}, true},
+		{[0]*int{}, false},
+	}
+
+	for _, tt := range tests {
+		typ := reflect.TypeOf(tt.typ)
+		if got := hasPointers(typ); got != tt.want {
+			t.Errorf("hasPointers(%v) = %v, want %v", typ, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// PtrSlice is a growable collection of arena-allocated values: both the
+// values and the slice of pointers to them live in the arena. It replaces
+// hand-built []Ptr[T] or Slice[*T] collections, whose pointers are easy to
+// keep or copy past the arena's lifetime; here At returns lifetime-checked
+// Ptr values and freeing or resetting the arena invalidates the whole
+// structure.
+//
+// The zero value is an empty PtrSlice bound to the arena of its first Append.
+// A PtrSlice is not safe for concurrent use.
+//
+// Example:
+//
+//	var nodes safearena.PtrSlice[Node]
+//	for k, v := range data {
+//	    nodes.Append(a, Node{Key: k, Value: v})
+//	}
+//	for i := 0; i < nodes.Len(); i++ {
+//	    visit(nodes.At(i).Get())
+//	}
+type PtrSlice[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the elements belong to
+	elems []*T   // Arena-backed; grown like AppendSlice
+}
+
+// Append allocates a copy of value in the arena and adds it to the end of
+// the slice.
+//
+// Panics if the arena has been freed or reset since the first Append, or if a
+// is not the arena the slice is bound to.
+func (s *PtrSlice[T]) Append(a *Arena, value T) {
+	if s.arena == nil {
+		if a.freed.Load() {
+			stack := captureStack(2)
+			panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+		}
+		s.arena, s.gen = a, a.gen.Load()
+	}
+	if a != s.arena {
+		panic("safearena: PtrSlice.Append with a different arena")
+	}
+	s.check()
+
+	if !a.charge(int64(unsafe.Sizeof(value))) {
+		stack := captureStack(2)
+		panic(a.chargeError(stack))
+	}
+	ptr := backingNew[T](a.inner)
+	*ptr = value
+	if a.debug != nil {
+		a.recordAlloc(unsafe.Pointer(ptr), captureStack(2), reflect.TypeFor[T](), unsafe.Sizeof(*ptr))
+	}
+
+	n := len(s.elems)
+	if n == cap(s.elems) {
+		grown := makeSlice[*T](a, n, max(2*n, 4))
+		copy(grown, s.elems)
+		s.elems = grown
+	}
+	s.elems = append(s.elems, ptr) // Fits in capacity; stays in the arena
+}
+
+// At returns a lifetime-checked pointer to the i'th element.
+//
+// Panics if i is out of range, or if the arena has been freed or reset.
+func (s *PtrSlice[T]) At(i int) Ptr[T] {
+	s.check()
+	if i < 0 || i >= len(s.elems) {
+		panic(indexError(i, len(s.elems)))
+	}
+	return Ptr[T]{ptr: s.elems[i], arena: s.arena, gen: s.gen}
+}
+
+// Len returns the number of elements.
+//
+// Panics if the arena has been freed or reset.
+func (s *PtrSlice[T]) Len() int {
+	s.check()
+	return len(s.elems)
+}
+
+// check panics if the slice's arena has been freed or reset.
+// An empty, unbound slice is always valid.
+func (s *PtrSlice[T]) check() {
+	if s.arena == nil {
+		return
+	}
+	if s.arena.freed.Load() || !s.arena.live(s.gen) {
+		stack := captureStack(3)
+		panic(s.arena.staleError(s.gen, stack, nil))
+	}
+}
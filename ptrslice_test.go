@@ -0,0 +1,101 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPtrSlice(t *testing.T) {
+	type node struct {
+		Key   string
+		Value int
+	}
+
+	a := New()
+	var nodes PtrSlice[node]
+	if nodes.Len() != 0 {
+		t.Errorf("expected empty slice, got length %d", nodes.Len())
+	}
+	for i := 0; i < 100; i++ {
+		nodes.Append(a, node{Key: fmt.Sprint("k", i), Value: i})
+	}
+
+	first := nodes.At(0)
+	sum := 0
+	for i := 0; i < nodes.Len(); i++ {
+		sum += nodes.At(i).Get().Value
+	}
+	if sum != 4950 {
+		t.Errorf("expected sum 4950, got %d", sum)
+	}
+	nodes.At(99).Get().Value = -1
+	if nodes.At(99).Deref().Value != -1 {
+		t.Error("expected At to point at the stored element")
+	}
+	if first.Get().Key != "k0" {
+		t.Error("expected element pointers to survive growth")
+	}
+
+	a.Free()
+	for name, access := range map[string]func(){
+		"Len":     func() { nodes.Len() },
+		"At":      func() { nodes.At(0) },
+		"Append":  func() { nodes.Append(a, node{}) },
+		"element": func() { first.Get() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func TestPtrSliceMisuse(t *testing.T) {
+	a, b := New(), New()
+	defer a.Free()
+	defer b.Free()
+
+	var s PtrSlice[int]
+	s.Append(a, 1)
+
+	for name, tt := range map[string]struct {
+		fn   func()
+		want string
+	}{
+		"out of range":    {func() { s.At(1) }, "out of range"},
+		"different arena": {func() { s.Append(b, 2) }, "different arena"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tt.want) {
+					t.Errorf("expected %q panic, got %q", tt.want, msg)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestPtrSliceReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	var s PtrSlice[int]
+	s.Append(a, 1)
+	a.Reset()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after reset") {
+			t.Errorf("expected use after reset panic, got %q", msg)
+		}
+	}()
+	s.At(0)
+}
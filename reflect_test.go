@@ -0,0 +1,71 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type reflectRow struct {
+	ID   int
+	Name string
+}
+
+func TestAllocReflect(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p := AllocReflect(a, reflect.TypeFor[reflectRow]())
+	v := p.Get()
+	v.Field(0).SetInt(42)
+	v.Field(1).SetString("row")
+
+	if p.Type() != reflect.TypeFor[reflectRow]() {
+		t.Errorf("expected reflectRow, got %v", p.Type())
+	}
+	if got := v.Interface().(reflectRow); got != (reflectRow{ID: 42, Name: "row"}) {
+		t.Errorf("expected {42 row}, got %+v", got)
+	}
+	if got := a.Stats().Bytes; got != int64(reflect.TypeFor[reflectRow]().Size()) {
+		t.Errorf("expected value size charged, got %d", got)
+	}
+}
+
+func TestAllocReflectAfterFree(t *testing.T) {
+	a := New()
+	p := AllocReflect(a, reflect.TypeFor[int]())
+	a.Free()
+
+	if p.Valid() {
+		t.Error("expected ReflectPtr to be invalid after free")
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	p.Get()
+}
+
+func BenchmarkAllocReflect(b *testing.B) {
+	typ := reflect.TypeFor[reflectRow]()
+
+	b.Run("Alloc", func(b *testing.B) {
+		a := New()
+		defer a.Free()
+		for i := 0; i < b.N; i++ {
+			Alloc(a, reflectRow{}).Get().ID = i
+		}
+	})
+
+	b.Run("AllocReflect", func(b *testing.B) {
+		a := New()
+		defer a.Free()
+		for i := 0; i < b.N; i++ {
+			AllocReflect(a, typ).Get().Field(0).SetInt(int64(i))
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package safearena
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"weak"
+)
+
+// liveArenas holds every debug-mode arena that has not been freed. It keeps
+// weak pointers, so an arena dropped without Free disappears from it once
+// collected (and NewWithFinalizer can still report it).
+var liveArenas sync.Map // uint64 id -> weak.Pointer[Arena]
+
+// ArenaInfo describes a live arena for LiveArenas
+type ArenaInfo struct {
+	ID      uint64
+	Name    string        // From NewNamed, or ""
+	Created time.Time     // When New was called
+	Age     time.Duration // Time since creation, as of the LiveArenas call
+	Site    string        // Where the arena was created, as "file:line (function)", or "unknown"
+	Stats   Stats
+}
+
+// LiveArenas lists the debug-mode arenas (see Debug) that have been created
+// and not yet freed, oldest first, to find arenas that are leaking while a
+// program runs, rather than when the garbage collector notices (see
+// NewWithFinalizer). Arenas created without Debug are not tracked, so the
+// registry costs nothing in production.
+//
+// An arena dropped without Free stays listed until it is garbage collected.
+//
+// Example:
+//
+//	http.HandleFunc("/debug/arenas", func(w http.ResponseWriter, r *http.Request) {
+//	    for _, info := range safearena.LiveArenas() {
+//	        fmt.Fprintf(w, "arena %d: %v old, %d bytes, created at %s\n",
+//	            info.ID, info.Age, info.Stats.Bytes, info.Site)
+//	    }
+//	})
+func LiveArenas() []ArenaInfo {
+	now := time.Now()
+	var infos []ArenaInfo
+	liveArenas.Range(func(_, v any) bool {
+		a := v.(weak.Pointer[Arena]).Value()
+		if a == nil || a.freed.Load() {
+			return true // Collected, or freed since the Range began
+		}
+		infos = append(infos, ArenaInfo{
+			ID:      a.id,
+			Name:    a.name,
+			Created: a.debug.created,
+			Age:     now.Sub(a.debug.created),
+			Site:    siteString(creationSite(a.debug.site)),
+			Stats:   a.Stats(),
+		})
+		return true
+	})
+	slices.SortFunc(infos, func(x, y ArenaInfo) int {
+		return cmp.Compare(x.ID, y.ID)
+	})
+	return infos
+}
+
+// registerLive adds a debug-mode arena to the registry. stack is the stack
+// at creation.
+func registerLive(a *Arena, stack *stackInfo) {
+	a.debug.created = time.Now()
+	a.debug.site = stack
+	liveArenas.Store(a.id, weak.Make(a))
+}
+
+// creationSite returns the first frame of stack outside this package, so
+// that arenas created through NewNamed, Scoped, and other wrappers report
+// their caller rather than the wrapper. Frames in this package's tests count
+// as outside.
+func creationSite(stack *stackInfo) *stackInfo {
+	for _, f := range stack.frames() {
+		if !strings.HasPrefix(f.Function, "safearena.") || strings.HasSuffix(f.File, "_test.go") {
+			return &stackInfo{Frame: f}
+		}
+	}
+	return nil
+}
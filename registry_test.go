@@ -0,0 +1,49 @@
+package safearena
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLiveArenas(t *testing.T) {
+	enableDebug(t)
+
+	a, b, c := New(), NewNamed("cache"), New()
+	Alloc(b, int64(1))
+	defer a.Free()
+	defer b.Free()
+	c.Free()
+
+	listed := make(map[uint64]ArenaInfo)
+	for _, info := range LiveArenas() {
+		listed[info.ID] = info
+	}
+	if _, ok := listed[c.id]; ok {
+		t.Error("expected the freed arena to be unlisted")
+	}
+	for _, x := range []*Arena{a, b} {
+		info, ok := listed[x.id]
+		if !ok {
+			t.Fatalf("expected arena %d to be listed", x.id)
+		}
+		if info.Age <= 0 || info.Created.IsZero() {
+			t.Errorf("expected a creation time, got %+v", info)
+		}
+		if !strings.Contains(info.Site, "registry_test.go") {
+			t.Errorf("expected the creation site in the test, not the constructor, got %q", info.Site)
+		}
+	}
+	if info := listed[b.id]; info.Name != "cache" || info.Stats.Allocations != 1 {
+		t.Errorf("expected name and stats, got %+v", info)
+	}
+}
+
+func TestLiveArenasWithoutDebug(t *testing.T) {
+	a := New()
+	defer a.Free()
+	for _, info := range LiveArenas() {
+		if info.ID == a.id {
+			t.Error("expected arenas created without Debug to be untracked")
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package safearena
+
+import (
+	"io"
+)
+
+// AllocResource allocates r in the arena and ties its lifetime to the arena:
+// r.Close is registered with OnFree, so the resource is closed exactly once
+// when the arena is freed or reset. Close errors are reported by FreeErr.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	safearena.ScopedPtr(func(a *safearena.Arena) {
+//	    f, _ := os.CreateTemp("", "upload-*")
+//	    tmp := safearena.AllocResource(a, f)
+//	    io.Copy(*tmp.Get(), body)
+//	}) // f is closed here
+func AllocResource[T io.Closer](a *Arena, r T) Ptr[T] {
+	p := Alloc(a, r) // Panics if freed
+	a.OnFree(r.Close)
+	return p
+}
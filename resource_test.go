@@ -0,0 +1,80 @@
+package safearena
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeCloser counts Close calls and optionally fails
+type fakeCloser struct {
+	closes int
+	err    error
+}
+
+func (f *fakeCloser) Close() error {
+	f.closes++
+	return f.err
+}
+
+func TestAllocResourceClosedOnFree(t *testing.T) {
+	a := New()
+	r := &fakeCloser{}
+	p := AllocResource(a, r)
+
+	if *p.Get() != r {
+		t.Error("expected Ptr to hold the resource")
+	}
+	if r.closes != 0 {
+		t.Errorf("expected resource to stay open before free, got %d closes", r.closes)
+	}
+
+	a.Free()
+	if r.closes != 1 {
+		t.Errorf("expected exactly one close on free, got %d", r.closes)
+	}
+}
+
+func TestAllocResourceClosedOnReset(t *testing.T) {
+	a := New()
+	r := &fakeCloser{}
+	AllocResource(a, r)
+
+	a.Reset()
+	if r.closes != 1 {
+		t.Errorf("expected exactly one close on reset, got %d", r.closes)
+	}
+
+	// Already closed on reset; free must not close it again
+	a.Free()
+	if r.closes != 1 {
+		t.Errorf("expected no close on later free, got %d closes", r.closes)
+	}
+}
+
+func TestAllocResourceCloseErrors(t *testing.T) {
+	a := New()
+	errClose := errors.New("close failed")
+	AllocResource(a, &fakeCloser{err: errClose})
+	AllocResource(a, &fakeCloser{})
+	a.Free()
+
+	if err := a.FreeErr(); !errors.Is(err, errClose) {
+		t.Errorf("expected close error from FreeErr, got %v", err)
+	}
+}
+
+func TestAllocResourceAfterFree(t *testing.T) {
+	a := New()
+	a.Free()
+
+	r := &fakeCloser{}
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Error("expected panic allocating resource after free")
+		}
+		if r.closes != 0 {
+			t.Errorf("expected resource not to be closed, got %d closes", r.closes)
+		}
+	}()
+	AllocResource(a, r)
+}
@@ -0,0 +1,104 @@
+package safearena
+
+import (
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+)
+
+// RingBuffer is a fixed-capacity FIFO queue whose storage lives in the arena.
+// It never allocates after creation: Push reports false when the buffer is
+// full instead of growing.
+//
+// A RingBuffer is safe for one goroutine calling Push concurrently with one
+// goroutine calling Pop (single producer, single consumer).
+//
+// Every method panics if the arena has been freed or reset.
+type RingBuffer[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the storage belongs to
+	buf   []T
+	head  atomic.Uint64 // Next slot to pop; written only by the consumer
+	tail  atomic.Uint64 // Next slot to push; written only by the producer
+}
+
+// NewRingBuffer creates an empty RingBuffer that holds up to capacity elements.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	events := safearena.NewRingBuffer[Event](a, 256)
+//	events.Push(Event{Kind: "start"})
+//	ev, ok := events.Pop()
+func NewRingBuffer[T any](a *Arena, capacity int) *RingBuffer[T] {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	if !a.charge(int64(capacity) * int64(unsafe.Sizeof(*new(T)))) {
+		stack := captureStack(2)
+		panic(a.chargeError(stack))
+	}
+
+	buf := backingMakeSlice[T](a.inner, capacity, capacity)
+	if a.debug != nil && capacity > 0 {
+		a.recordSliceAlloc(unsafe.Pointer(unsafe.SliceData(buf)), captureStack(2),
+			reflect.TypeFor[[]T](), uintptr(capacity)*unsafe.Sizeof(buf[0]))
+	}
+
+	return &RingBuffer[T]{
+		arena: a,
+		gen:   a.gen.Load(),
+		buf:   buf,
+	}
+}
+
+// Push appends value to the back of the buffer.
+// It reports false, leaving the buffer unchanged, if the buffer is full.
+func (r *RingBuffer[T]) Push(value T) bool {
+	r.check()
+	tail := r.tail.Load()
+	if tail-r.head.Load() == uint64(len(r.buf)) {
+		return false
+	}
+	r.buf[tail%uint64(len(r.buf))] = value
+	r.tail.Store(tail + 1)
+	return true
+}
+
+// Pop removes and returns the value at the front of the buffer.
+// It reports false if the buffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	r.check()
+	var zero T
+	head := r.head.Load()
+	if head == r.tail.Load() {
+		return zero, false
+	}
+	slot := &r.buf[head%uint64(len(r.buf))]
+	value := *slot
+	*slot = zero // Drop references held by the popped element
+	r.head.Store(head + 1)
+	return value, true
+}
+
+// Len returns the number of elements in the buffer
+func (r *RingBuffer[T]) Len() int {
+	r.check()
+	return int(r.tail.Load() - r.head.Load())
+}
+
+// Cap returns the maximum number of elements the buffer can hold
+func (r *RingBuffer[T]) Cap() int {
+	r.check()
+	return len(r.buf)
+}
+
+// check panics if the buffer's arena has been freed or reset
+func (r *RingBuffer[T]) check() {
+	if r.arena.freed.Load() || !r.arena.live(r.gen) {
+		stack := captureStack(3)
+		panic(r.arena.staleError(r.gen, stack, nil))
+	}
+}
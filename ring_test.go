@@ -0,0 +1,109 @@
+package safearena
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	r := NewRingBuffer[int](a, 3)
+	if r.Cap() != 3 || r.Len() != 0 {
+		t.Fatalf("expected empty buffer of capacity 3, got len %d cap %d", r.Len(), r.Cap())
+	}
+
+	for i := 1; i <= 3; i++ {
+		if !r.Push(i) {
+			t.Fatalf("expected push %d to succeed", i)
+		}
+	}
+	if r.Push(4) {
+		t.Error("expected push beyond capacity to fail")
+	}
+	if r.Len() != 3 {
+		t.Errorf("expected length 3, got %d", r.Len())
+	}
+
+	// Wrap around: pop one, push one
+	if v, ok := r.Pop(); !ok || v != 1 {
+		t.Errorf("expected 1, got %d (ok=%v)", v, ok)
+	}
+	if !r.Push(4) {
+		t.Error("expected push after pop to succeed")
+	}
+
+	for _, want := range []int{2, 3, 4} {
+		if v, ok := r.Pop(); !ok || v != want {
+			t.Errorf("expected %d, got %d (ok=%v)", want, v, ok)
+		}
+	}
+	if _, ok := r.Pop(); ok {
+		t.Error("expected pop from empty buffer to fail")
+	}
+}
+
+func TestRingBufferSPSC(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	const n = 10000
+	r := NewRingBuffer[int](a, 16)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; {
+			if r.Push(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for want := 0; want < n; {
+		if v, ok := r.Pop(); ok {
+			if v != want {
+				t.Fatalf("expected %d, got %d", want, v)
+			}
+			want++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+}
+
+func TestRingBufferAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(r *RingBuffer[int])
+	}{
+		{"push", func(r *RingBuffer[int]) { r.Push(1) }},
+		{"pop", func(r *RingBuffer[int]) { r.Pop() }},
+		{"len", func(r *RingBuffer[int]) { r.Len() }},
+		{"cap", func(r *RingBuffer[int]) { r.Cap() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			r := NewRingBuffer[int](a, 4)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use(r)
+		})
+	}
+}
@@ -3,10 +3,13 @@
 package safearena
 
 import (
-	"arena"
 	"fmt"
+	"reflect"
 	"runtime"
+	"sync"
 	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // Approach 1: Type-based safety with runtime checks
@@ -14,9 +17,25 @@ import (
 
 // Arena wraps Go's arena with lightweight lifetime tracking
 type Arena struct {
-	inner *arena.Arena
+	inner *backing
 	id    uint64
 	freed atomic.Bool
+	gen   atomic.Uint64 // Incremented by Reset, Mark, and Release
+	floor atomic.Uint64 // Oldest generation still valid; raised by Reset
+	debug *debugState   // Non-nil when Debug was set at creation
+	name  string        // Label from NewNamed, shown in messages
+
+	released atomic.Pointer[[]genRange] // Generations invalidated by Release
+	cleanup  cleanupState               // OnFree callbacks
+	stats    statsCounters              // Allocation counts for Stats and the budget
+	limit    int64                      // Byte budget from NewWithLimit; 0 means unlimited
+	frozen   atomic.Bool                // Set by Arena.Freeze; rejects new allocations
+	taken    atomic.Pointer[takenSet]   // Slice data moved out by TakeBytes
+
+	reserved []byte        // Unused memory from Reserve, carved by makeBytes
+	watchdog *time.Timer   // NewWithMaxAge warning or NewWithTTL expiry; stopped by Free
+	ttl      atomic.Int32  // ttlNone, or the state of a NewWithTTL arena
+	readers  *sync.RWMutex // Reader lock from NewConcurrent and NewWithTTL; held exclusively by Free and Reset
 	// Removed: objects sync.Map (unused, caused 10x slowdown)
 }
 
@@ -25,11 +44,34 @@ type Arena struct {
 type Ptr[T any] struct {
 	ptr   *T
 	arena *Arena // Keep reference to prevent premature freeing
+	gen   uint64 // Arena generation at allocation; costs 8 bytes but makes Reset safe
 	// Removed: arenaID (can get from arena.id, saves 8 bytes per pointer)
 }
 
+// Sizes of Ptr and Slice: two words and a generation for Ptr, a slice header,
+// an arena pointer, and a generation for Slice (24 and 40 bytes on 64-bit).
+// Every Ptr and Slice a program holds pays for each field, so new fields need
+// a very good reason; TestPtrSize fails if these drift.
+const (
+	ptrSize   = 2*unsafe.Sizeof(uintptr(0)) + 8
+	sliceSize = 4*unsafe.Sizeof(uintptr(0)) + 8
+)
+
+// Fail the build, not just the tests, if Ptr or Slice grows
+var (
+	_ [ptrSize - unsafe.Sizeof(Ptr[int]{})]struct{}
+	_ [sliceSize - unsafe.Sizeof(Slice[int]{})]struct{}
+)
+
 var arenaCounter atomic.Uint64
 
+// ArenasEnabled reports whether the package was built with
+// GOEXPERIMENT=arenas. When false, arenas allocate from the garbage-collected
+// heap instead, with identical lifetime checks.
+func ArenasEnabled() bool {
+	return arenasEnabled
+}
+
 // New creates a new safe arena with runtime safety checks.
 // The arena must be freed with Free() when done, typically via defer.
 //
@@ -39,10 +81,43 @@ var arenaCounter atomic.Uint64
 //	defer a.Free()
 //	data := safearena.Alloc(a, MyStruct{})
 func New() *Arena {
-	return &Arena{
-		inner: arena.NewArena(),
+	debug := Debug
+	if debug {
+		defer recordTiming(opNew, time.Now()) // Includes creating the backing arena
+	}
+	a := &Arena{
+		inner: newBacking(),
 		id:    arenaCounter.Add(1),
 	}
+	if debug {
+		a.debug = newDebugState()
+		a.debug.checkAliases = CheckAliases
+		registerLive(a, captureStack(2))
+	}
+	if observer != nil {
+		observer.Created(a.id)
+	}
+	return a
+}
+
+// NewNamed is like New but labels the arena with name. The name is shown
+// next to the arena's id in panic messages and leak warnings, such as
+// "arena 7 (request-handler): use after free", to tell arenas of different
+// subsystems apart in logs.
+//
+// Example:
+//
+//	a := safearena.NewNamed("request-handler")
+//	defer a.Free()
+func NewNamed(name string) *Arena {
+	a := New()
+	a.name = name
+	return a
+}
+
+// Name returns the arena's label from NewNamed, or "" if it has none.
+func (a *Arena) Name() string {
+	return a.name
 }
 
 // Alloc allocates a value in the arena and returns a safe pointer.
@@ -55,19 +130,31 @@ func New() *Arena {
 //	data := safearena.Alloc(a, MyStruct{Field: "value"})
 //	ptr := data.Get() // Safe while arena is alive
 func Alloc[T any](a *Arena, value T) Ptr[T] {
+	if a.debug != nil {
+		defer recordTiming(opAlloc, time.Now())
+	}
 	if a.freed.Load() {
 		stack := captureStack(2)
-		panic(errorWithHint(a.id, "allocation after free", stack, hintAllocAfterFree))
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	if !a.charge(int64(unsafe.Sizeof(value))) {
+		stack := captureStack(2)
+		panic(a.chargeError(stack))
 	}
 
-	ptr := arena.New[T](a.inner)
+	ptr := backingNew[T](a.inner)
 	*ptr = value
 
 	// No tracking needed - removed for 10x performance improvement
+	// (debug mode only: record the allocation site for diagnostics)
+	if a.debug != nil {
+		a.recordAlloc(unsafe.Pointer(ptr), captureStack(2), reflect.TypeFor[T](), unsafe.Sizeof(*ptr))
+	}
 
 	return Ptr[T]{
 		ptr:   ptr,
 		arena: a,
+		gen:   a.gen.Load(),
 	}
 }
 
@@ -75,7 +162,7 @@ func Alloc[T any](a *Arena, value T) Ptr[T] {
 // Returns a pointer to the arena-allocated value.
 //
 // Panics if the arena has been freed with a helpful error message including
-// stack trace and recovery hints.
+// stack trace and recovery hints, unless OnViolation tolerates the access.
 //
 // Example:
 //
@@ -83,15 +170,46 @@ func Alloc[T any](a *Arena, value T) Ptr[T] {
 //	value := data.Get() // Returns *int
 //	fmt.Println(*value)
 func (p Ptr[T]) Get() *T {
-	if p.arena.freed.Load() {
-		stack := captureStack(2)
-		panic(errorWithHint(p.arena.id, "use after free", stack, hintUseAfterFree))
+	if p.arena.freed.Load() || !p.arena.live(p.gen) {
+		return p.stale()
+	}
+	if p.arena.debug != nil {
+		p.arena.checkOwner(unsafe.Pointer(p.ptr))
 	}
 	return p.ptr
 }
 
+// stale handles Get on a Ptr that is no longer valid: it panics, or returns
+// a pointer to a fresh zero value if OnViolation tolerates the access
+func (p Ptr[T]) stale() *T {
+	stack := captureStack(3)
+	site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+	err := p.arena.staleError(p.gen, stack, site)
+	if !tolerate(err) {
+		panic(err)
+	}
+	return new(T)
+}
+
+// Valid reports whether Get would succeed: the arena has not been freed, or
+// reset since the value was allocated. The zero Ptr is not valid.
+//
+// The result is only a snapshot. If another goroutine may free or reset the
+// arena concurrently, Get can still panic right after Valid returns true.
+//
+// Example:
+//
+//	if !p.Valid() {
+//	    return errStale
+//	}
+//	use(p.Get())
+func (p Ptr[T]) Valid() bool {
+	return p.arena != nil && !p.arena.freed.Load() && p.arena.live(p.gen)
+}
+
 // Deref dereferences and returns a copy of the value.
 // Unlike Get(), this returns the value itself, not a pointer.
+// The copy is shallow unless deep mode is enabled with SetDerefDeep.
 //
 // Panics if the arena has been freed.
 //
@@ -101,7 +219,11 @@ func (p Ptr[T]) Get() *T {
 //	value := data.Deref() // Returns int (not *int)
 //	fmt.Println(value)
 func (p Ptr[T]) Deref() T {
-	return *p.Get()
+	val := *p.Get()
+	if derefDeep.Load() {
+		return deepCopy(val)
+	}
+	return val
 }
 
 // Free safely frees the arena and all its allocations.
@@ -111,17 +233,83 @@ func (p Ptr[T]) Deref() T {
 // Panics on double-free to prevent memory corruption.
 // Typically used with defer for automatic cleanup.
 //
+// In debug mode (see Debug), Free first poisons the memory of every tracked
+// allocation with a repeating 0xDE 0xAD pattern, so reads through a raw
+// pointer or slice kept past Free see obvious garbage rather than plausible
+// data. Allocations whose type contains pointers are zeroed instead, since
+// garbage pointers would crash the garbage collector.
+//
 // Example:
 //
 //	a := safearena.New()
 //	defer a.Free() // Automatic cleanup
 //	// Use arena...
 func (a *Arena) Free() {
-	if !a.freed.CompareAndSwap(false, true) {
+	if !a.tryFree(3, (*backing).Free) && !a.expired() {
 		stack := captureStack(2)
-		panic(errorWithHint(a.id, "double free", stack, hintDoubleFree))
+		panic(errorWithHint(a, "double free", stack, DoubleFree))
+	}
+}
+
+// TryFree is like Free but reports false instead of panicking if the arena
+// has already been freed, and true if this call freed it. Use it in layered
+// cleanup code where several deferred paths may each try to free the same
+// arena; keep using Free elsewhere so that real double frees are caught.
+//
+// Example:
+//
+//	defer a.TryFree() // Safety net; the happy path frees earlier
+func (a *Arena) TryFree() bool {
+	return a.tryFree(3, (*backing).Free)
+}
+
+// tryFree implements Free, TryFree, and FreeAsync, handing the underlying
+// arena to release once the Arena is torn down. skip locates the caller's
+// frame for captureStack, as seen from tryFree.
+func (a *Arena) tryFree(skip int, release func(*backing)) bool {
+	if a.debug != nil {
+		defer recordTiming(opFree, time.Now())
+	}
+	if a.readers != nil {
+		a.readers.Lock()
+		defer a.readers.Unlock()
+	}
+	if a.debug != nil && a.debug.checkAliases && !a.freed.Load() {
+		a.checkAliases(skip + 1)
+	}
+	a.claimFree()
+	if !a.freed.CompareAndSwap(false, true) {
+		return false
+	}
+	if a.debug != nil {
+		liveArenas.Delete(a.id)
+	}
+	if !a.expired() && a.watchdog != nil { // An expiring timer may run before NewWithTTL has stored it
+		a.watchdog.Stop()
+	}
+	a.runCleanups()
+	if a.debug != nil {
+		a.debug.poison(a.floor.Load(), a.gen.Load())
+		if a.debug.tracing {
+			traceReport(a.Report())
+		}
+	}
+	release(a.inner)
+	if OnFree != nil {
+		OnFree(a.id, int(a.stats.bytes.Load()))
 	}
-	a.inner.Free()
+	if observer != nil {
+		observer.Freed(a.id, a.Stats())
+	}
+	return true
+}
+
+// IsFreed reports whether the arena has been freed, without panicking.
+//
+// The result is only a snapshot: if another goroutine may call Free
+// concurrently, the arena can be freed right after IsFreed returns false.
+func (a *Arena) IsFreed() bool {
+	return a.freed.Load()
 }
 
 // Scoped executes a function with an arena that's automatically freed.
@@ -131,6 +319,10 @@ func (a *Arena) Free() {
 // The function can return any heap-allocated value safely.
 // Do not return Ptr[T] values - they will be invalid after Scoped returns.
 //
+// In debug mode (see Debug), allocating into the arena of an outer Scoped
+// call from inside a nested one prints a warning naming the allocation site,
+// since it usually means the wrong arena variable was captured.
+//
 // Example:
 //
 //	result := safearena.Scoped(func(a *safearena.Arena) Response {
@@ -141,6 +333,9 @@ func (a *Arena) Free() {
 func Scoped[R any](fn func(*Arena) R) R {
 	a := New()
 	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
 	return fn(a)
 }
 
@@ -149,12 +344,16 @@ func Scoped[R any](fn func(*Arena) R) R {
 func ScopedPtr(fn func(*Arena)) {
 	a := New()
 	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
 	fn(a)
 }
 
 // Clone copies a value from the arena to the heap.
 // Use this when you need to preserve arena-allocated data beyond the arena's lifetime.
 // The returned pointer is heap-allocated and safe to use after the arena is freed.
+// Like Deref, the copy is shallow unless deep mode is enabled with SetDerefDeep.
 //
 // Panics if the arena has already been freed.
 //
@@ -166,17 +365,116 @@ func ScopedPtr(fn func(*Arena)) {
 //	a.Free()
 //	fmt.Println(heapCopy.Port) // Safe - heapCopy is on heap
 func Clone[T any](p Ptr[T]) *T {
-	val := p.Deref() // Get the value (panics if freed)
+	if p.arena.freed.Load() {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(errorWithSites(p.arena, "Clone() called after free", stack, site, CloneAfterFree))
+	}
+
+	val := p.Deref() // Get the value (panics if reset)
 	heapCopy := new(T)
 	*heapCopy = val
 	return heapCopy
 }
 
+// CloneInto copies a value from the arena into dst, like Clone but reusing
+// caller-provided storage instead of allocating, for zero-allocation
+// extraction in tight loops. The copy follows the same shallow/deep rules as
+// Clone.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	var cfg Config
+//	for _, p := range configs {
+//	    safearena.CloneInto(&cfg, p)
+//	    apply(&cfg)
+//	}
+func CloneInto[T any](dst *T, p Ptr[T]) {
+	if p.arena.freed.Load() {
+		stack := captureStack(2)
+		site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+		panic(errorWithSites(p.arena, "CloneInto() called after free", stack, site, CloneAfterFree))
+	}
+
+	*dst = p.Deref() // Panics if reset
+}
+
+// CloneAll copies the values of ps to a heap slice, the bulk form of Clone
+// for extracting a collection built in one or more arenas. The copies follow
+// the same shallow/deep rules as Clone.
+//
+// Every Ptr is checked before anything is copied, so a stale collection
+// fails with a single panic that reports how many of its values are stale,
+// rather than partway through the copy.
+//
+// Panics if the arena of any Ptr has been freed or reset since allocation.
+//
+// Example:
+//
+//	nodes := make([]safearena.Ptr[Node], 0, n)
+//	// ... build nodes in the arena ...
+//	result := safearena.CloneAll(nodes)
+//	a.Free() // result is on the heap
+func CloneAll[T any](ps []Ptr[T]) []T {
+	checkCloneAll("CloneAll", ps)
+	out := make([]T, len(ps))
+	for i, p := range ps {
+		out[i] = p.Deref()
+	}
+	return out
+}
+
+// ClonePtrs is like CloneAll but returns a heap pointer per value, for code
+// that needs *T. The values share one heap allocation.
+//
+// Panics if the arena of any Ptr has been freed or reset since allocation.
+func ClonePtrs[T any](ps []Ptr[T]) []*T {
+	checkCloneAll("ClonePtrs", ps)
+	values := make([]T, len(ps))
+	out := make([]*T, len(ps))
+	for i, p := range ps {
+		values[i] = p.Deref()
+		out[i] = &values[i]
+	}
+	return out
+}
+
+// checkCloneAll panics, once for the whole batch, if any of ps is no longer
+// valid; fn names the caller in the message
+func checkCloneAll[T any](fn string, ps []Ptr[T]) {
+	first, stale := -1, 0
+	for i, p := range ps {
+		if p.arena.freed.Load() || !p.arena.live(p.gen) {
+			if first < 0 {
+				first = i
+			}
+			stale++
+		}
+	}
+	if stale == 0 {
+		return
+	}
+
+	p := ps[first]
+	stack := captureStack(3)
+	site := p.arena.allocSite(unsafe.Pointer(p.ptr))
+	err := p.arena.staleError(p.gen, stack, site)
+	if err.Kind == UseAfterFree {
+		err.Kind = CloneAfterFree
+	}
+	err.what = fmt.Sprintf("%s() called with %d of %d values stale (first at index %d): %s",
+		fn, stale, len(ps), first, err.what)
+	panic(err)
+}
+
 // Slice is an arena-allocated slice with lifetime tracking.
 // Like Ptr[T], it tracks the arena lifetime and panics on use-after-free.
 type Slice[T any] struct {
 	slice []T
 	arena *Arena
+	gen   uint64 // Arena generation at allocation
 }
 
 // AllocSlice allocates a slice in the arena with the specified size.
@@ -190,24 +488,25 @@ type Slice[T any] struct {
 //	slice := buffer.Get()
 //	copy(slice, []byte("data"))
 func AllocSlice[T any](a *Arena, size int) Slice[T] {
+	if a.debug != nil {
+		defer recordTiming(opAllocSlice, time.Now())
+	}
 	if a.freed.Load() {
 		stack := captureStack(2)
-		panic(errorWithHint(a.id, "allocation after free", stack, hintAllocAfterFree))
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
 	}
 
-	// Allocate backing array in arena
-	slice := make([]T, size)
-
 	return Slice[T]{
-		slice: slice,
+		slice: makeSlice[T](a, size, size),
 		arena: a,
+		gen:   a.gen.Load(),
 	}
 }
 
 // Get returns the underlying slice with lifetime checking.
 // The returned slice is valid only while the arena is alive.
 //
-// Panics if the arena has been freed.
+// Panics if the arena has been freed, unless OnViolation tolerates the access.
 //
 // Example:
 //
@@ -217,11 +516,198 @@ func AllocSlice[T any](a *Arena, size int) Slice[T] {
 //	    slice[i] = i
 //	}
 func (s Slice[T]) Get() []T {
+	if s.arena.freed.Load() || !s.live() {
+		return s.stale()
+	}
+	return s.slice
+}
+
+// stale handles Get on a Slice that is no longer valid: it panics, or returns
+// a fresh zeroed heap slice of the same length and capacity if OnViolation
+// tolerates the access
+func (s Slice[T]) stale() []T {
+	stack := captureStack(3)
+	site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+	err := s.arena.staleError(s.gen, stack, site)
+	if !tolerate(err) {
+		panic(err)
+	}
+	return make([]T, len(s.slice), cap(s.slice))
+}
+
+// Valid reports whether Get would succeed: the arena has not been freed, or
+// reset since the slice was allocated. The zero Slice is not valid.
+//
+// Like Ptr.Valid, the result is only a snapshot when other goroutines may
+// free or reset the arena concurrently.
+func (s Slice[T]) Valid() bool {
+	return s.arena != nil && !s.arena.freed.Load() && s.live()
+}
+
+// At returns a pointer to element i, checking both the arena lifetime and the
+// index in one call. Use SetAt to store an element.
+//
+// Panics if the arena has been freed or reset, or if i is out of range.
+//
+// Example:
+//
+//	points := safearena.AllocSlice[Point](a, n)
+//	points.At(0).X = 1
+func (s Slice[T]) At(i int) *T {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if uint(i) >= uint(len(s.slice)) {
+		panic(indexError(i, len(s.slice)))
+	}
+	return &s.slice[i]
+}
+
+// Len returns the slice length without going through Get.
+//
+// Panics if the arena has been freed or reset.
+func (s Slice[T]) Len() int {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	return len(s.slice)
+}
+
+// Slice returns a lifetime-tracked view of s.Get()[low:high]. Unlike slicing
+// the result of Get, the view keeps the arena reference, so storing it and
+// using it after Free panics instead of reading freed memory. As with Go
+// slicing, high may extend up to the capacity of s.
+//
+// Panics if the arena has been freed or reset, or if the bounds are invalid.
+//
+// Example:
+//
+//	buf := safearena.AllocSlice[byte](a, 4096)
+//	header, body := buf.Slice(0, 16), buf.Slice(16, 4096)
+func (s Slice[T]) Slice(low, high int) Slice[T] {
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if low < 0 || high < low || high > cap(s.slice) {
+		panic(fmt.Sprintf("safearena: slice bounds [%d:%d] out of range with capacity %d", low, high, cap(s.slice)))
+	}
+	return Slice[T]{
+		slice: s.slice[low:high],
+		arena: s.arena,
+		gen:   s.gen,
+	}
+}
+
+// indexError creates the panic message for an out-of-range At or SetAt
+func indexError(i, length int) string {
+	return fmt.Sprintf("safearena: index %d out of range in freed-checked slice of length %d", i, length)
+}
+
+// Data returns the base pointer of the arena-backed array and its length,
+// for interop with C or reflection-based code that expects a raw buffer.
+//
+// This is unsafe: the returned pointer bypasses all future lifetime checks.
+// It must not be used after the arena is freed or reset, and nothing will detect it if it is.
+//
+// Panics if the arena has been freed.
+//
+// Example:
+//
+//	buf := safearena.AllocSlice[float32](a, 1024)
+//	ptr, n := buf.Data()
+//	C.process((*C.float)(ptr), C.int(n))
+func (s Slice[T]) Data() (unsafe.Pointer, int) {
+	return s.dataPtr(3), len(s.slice)
+}
+
+// DataPtr returns the base pointer of the arena-backed array, for handing a
+// buffer to C without copying it.
+//
+// Arena memory is never moved: the garbage collector does not relocate it,
+// and nothing else does either, so the pointer stays valid and stable, and
+// every call returns the same address, until the arena is freed or reset.
+// That makes it safe to pass to cgo calls for the arena's lifetime. The usual
+// cgo pointer rules still apply (the buffer must not contain Go pointers, and
+// C must not keep the pointer after the call returns), and, as with Data,
+// nothing checks the pointer once it has been returned.
+//
+// Panics if the arena has been freed.
+//
+// Example:
+//
+//	frame := safearena.AllocSlice[byte](a, frameSize)
+//	C.decode_frame(src, (*C.uint8_t)(frame.DataPtr()), C.size_t(frame.Len()))
+func (s Slice[T]) DataPtr() unsafe.Pointer {
+	return s.dataPtr(3)
+}
+
+// dataPtr returns the slice's base pointer after checking that it is live.
+// skip is the captureStack depth of the public caller's caller.
+func (s Slice[T]) dataPtr(skip int) unsafe.Pointer {
+	data := unsafe.Pointer(unsafe.SliceData(s.slice))
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(skip)
+		site := s.arena.allocSite(data)
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	return data
+}
+
+// CloneSlice copies an arena slice to the heap.
+// Use this to return a processed buffer past the arena boundary.
+// The returned slice has the same length and is safe to use after the arena is freed.
+//
+// The copy is shallow: for a Slice[*T] the pointers themselves are copied,
+// not the values they point to.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	body := safearena.Scoped(func(a *safearena.Arena) []byte {
+//	    buf := safearena.AllocSlice[byte](a, n)
+//	    fill(buf.Get())
+//	    return safearena.CloneSlice(buf) // Copy to heap
+//	})
+func CloneSlice[T any](s Slice[T]) []T {
 	if s.arena.freed.Load() {
 		stack := captureStack(2)
-		panic(errorWithHint(s.arena.id, "use after free", stack, hintUseAfterFree))
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(errorWithSites(s.arena, "CloneSlice() called after free", stack, site, CloneAfterFree))
 	}
-	return s.slice
+
+	src := s.Get() // Panics if reset
+	heapCopy := make([]T, len(src))
+	copy(heapCopy, src)
+	return heapCopy
+}
+
+// CloneSliceInto copies elements of an arena slice into dst, like the copy
+// builtin, and returns the number copied: the minimum of len(dst) and the
+// slice length. Use it instead of CloneSlice to reuse a destination buffer.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	buf := make([]byte, 4096)
+//	n := safearena.CloneSliceInto(buf, payload)
+//	a.Free()
+//	send(buf[:n]) // Safe - buf is heap memory
+func CloneSliceInto[T any](dst []T, s Slice[T]) int {
+	if s.arena.freed.Load() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(errorWithSites(s.arena, "CloneSliceInto() called after free", stack, site, CloneAfterFree))
+	}
+
+	return copy(dst, s.Get()) // Panics if reset
 }
 
 // StringBuilder is an example of a safe arena-based string builder.
@@ -247,34 +733,54 @@ func NewStringBuilder(a *Arena, capacity int) Ptr[StringBuilder] {
 }
 
 // Append adds a string to the StringBuilder.
+// If the string does not fit, the buffer grows from the arena.
 func (sb *StringBuilder) Append(s string) {
-	buf := sb.buffers.Get()
-	copy(buf[sb.length:], s)
-	sb.length += len(s)
+	sb.buffers.slice, sb.length = appendString(sb.buffers.Get(), sb.length, s, sb.buffers.arena.growBuf)
 }
 
 // String returns the current string content of the StringBuilder.
 func (sb *StringBuilder) String() string {
-	buf := sb.buffers.Get()
-	return string(buf[:sb.length])
+	return builtString(sb.buffers.Get(), sb.length)
+}
+
+// appendString writes s into buf after the first length bytes and returns
+// the buffer and the new length. If s does not fit, the content is first
+// copied into a new buffer from growBuf, at least double the size.
+// Shared by StringBuilder and StringBuilderOpt.
+func appendString(buf []byte, length int, s string, growBuf func(size int) []byte) ([]byte, int) {
+	if length+len(s) > len(buf) {
+		size := max(2*len(buf), length+len(s))
+		grown := growBuf(size)
+		copy(grown, buf[:length])
+		buf = grown
+	}
+	copy(buf[length:], s)
+	return buf, length + len(s)
+}
+
+// builtString returns the first length bytes of buf as a string.
+// Shared by StringBuilder and StringBuilderOpt.
+func builtString(buf []byte, length int) string {
+	return string(buf[:length])
 }
 
 // NewWithFinalizer creates an arena with a finalizer that detects leaked arenas.
-// The finalizer warns if the arena is garbage collected without being freed.
-// This is useful for debugging but adds overhead, so use New() in production.
+// If the arena is garbage collected without being freed, the finalizer
+// increments LeaksDetected and calls OnLeak, or logs a warning if OnLeak is
+// nil. The finalizer adds overhead to arena creation and collection.
 //
 // Example:
 //
 //	a := safearena.NewWithFinalizer()
 //	defer a.Free() // Make sure to call Free()
-//	// If you forget to Free(), you'll see a warning at GC time
+//	// If you forget to Free(), the leak is reported at GC time
 func NewWithFinalizer() *Arena {
 	a := New()
 
 	// Set finalizer to detect use-after-GC
 	runtime.SetFinalizer(a, func(a *Arena) {
 		if !a.freed.Load() {
-			fmt.Printf("WARNING: arena %d was GC'd without being freed!\n", a.id)
+			reportLeak(a)
 		}
 	})
 
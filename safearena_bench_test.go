@@ -110,3 +110,52 @@ func BenchmarkSingleIntHeap(b *testing.B) {
 		_ = *p
 	}
 }
+
+// Benchmark the optimized version with allocation counters enabled
+func BenchmarkOptimizedAllocStats(b *testing.B) {
+	b.Run("NewOpt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a := NewOpt()
+			for j := 0; j < 100; j++ {
+				_ = AllocOpt(a, j)
+			}
+			a.Free()
+		}
+	})
+	b.Run("NewOptWithStats", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			a := NewOptWithStats()
+			for j := 0; j < 100; j++ {
+				_ = AllocOpt(a, j)
+			}
+			a.Free()
+		}
+	})
+}
+
+// Benchmark UnsafeAlloc against AllocOpt: the difference is the freed check
+// in each Get
+func BenchmarkUnsafeAlloc(b *testing.B) {
+	b.Run("AllocOpt", func(b *testing.B) {
+		a := NewOpt()
+		defer a.Free()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p := AllocOpt(a, i)
+			for j := 0; j < 10; j++ {
+				*p.Get() += j
+			}
+		}
+	})
+	b.Run("UnsafeAlloc", func(b *testing.B) {
+		a := NewOpt()
+		defer a.Free()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p := UnsafeAlloc(a, i)
+			for j := 0; j < 10; j++ {
+				*p += j
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@ package safearena
 
 import (
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -140,6 +141,25 @@ func TestAllocSliceOpt(t *testing.T) {
 	}
 }
 
+// Test that slice allocators use arena memory, not the garbage-collected heap
+func TestAllocSliceNoHeap(t *testing.T) {
+	if !ArenasEnabled() {
+		t.Skip("slices come from the heap without GOEXPERIMENT=arenas")
+	}
+
+	a := New()
+	defer a.Free()
+	opt := NewOpt()
+	defer opt.Free()
+
+	if n := testing.AllocsPerRun(100, func() { AllocSlice[int](a, 64) }); n != 0 {
+		t.Errorf("expected AllocSlice to make no heap allocations, got %v", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { AllocSliceOpt[int](opt, 64) }); n != 0 {
+		t.Errorf("expected AllocSliceOpt to make no heap allocations, got %v", n)
+	}
+}
+
 // Test optimized version: UnsafeGet
 func TestUnsafeGet(t *testing.T) {
 	a := NewOpt()
@@ -173,6 +193,63 @@ func TestSetFinalizer(t *testing.T) {
 	runtime.GC()
 }
 
+// Test optimized version: ScopedPtrOpt
+func TestScopedPtrOpt(t *testing.T) {
+	var arena *ArenaOpt
+	ScopedPtrOpt(func(a *ArenaOpt) {
+		arena = a
+		p := AllocOpt(a, 42)
+		if *p.Get() != 42 {
+			t.Error("expected 42")
+		}
+	})
+
+	if arena == nil {
+		t.Fatal("ScopedPtrOpt function not executed")
+	}
+	if !arena.freed.Load() {
+		t.Error("expected arena to be freed after ScopedPtrOpt returns")
+	}
+}
+
+// Test optimized version: NewOptWithFinalizer
+func TestNewOptWithFinalizer(t *testing.T) {
+	a := NewOptWithFinalizer()
+	p := AllocOpt(a, "test")
+
+	if *p.Get() != "test" {
+		t.Error("expected test")
+	}
+
+	a.Free()
+
+	// Verify panic after free
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on use-after-free")
+		}
+	}()
+	_ = p.Get()
+}
+
+// Test optimized version: StringBuilderOpt
+func TestStringBuilderOpt(t *testing.T) {
+	result := ScopedOpt(func(a *ArenaOpt) string {
+		sb := NewStringBuilderOpt(a, 100)
+
+		builder := sb.Get()
+		builder.Append("Hello")
+		builder.Append(" ")
+		builder.Append("World")
+
+		return builder.String()
+	})
+
+	if result != "Hello World" {
+		t.Errorf("expected 'Hello World', got '%s'", result)
+	}
+}
+
 // Test optimized version: use after free
 func TestOptUseAfterFree(t *testing.T) {
 	a := NewOpt()
@@ -363,6 +440,38 @@ func TestStringBuilder(t *testing.T) {
 	}
 }
 
+// Test StringBuilder growing past its initial capacity
+func TestStringBuilderGrowth(t *testing.T) {
+	chunk := strings.Repeat("x", 50)
+
+	result := Scoped(func(a *Arena) string {
+		sb := NewStringBuilder(a, 100)
+		builder := sb.Get()
+		for i := 0; i < 3; i++ {
+			builder.Append(chunk)
+		}
+		builder.Append("end")
+		return builder.String()
+	})
+
+	if want := strings.Repeat(chunk, 3) + "end"; result != want {
+		t.Errorf("expected %d bytes, got %d", len(want), len(result))
+	}
+
+	resultOpt := ScopedOpt(func(a *ArenaOpt) string {
+		sb := NewStringBuilderOpt(a, 0)
+		builder := sb.Get()
+		for i := 0; i < 3; i++ {
+			builder.Append(chunk)
+		}
+		return builder.String()
+	})
+
+	if want := strings.Repeat(chunk, 3); resultOpt != want {
+		t.Errorf("expected %d bytes from StringBuilderOpt, got %d", len(want), len(resultOpt))
+	}
+}
+
 // Test empty slice
 func TestEmptySlice(t *testing.T) {
 	Scoped(func(a *Arena) int {
@@ -3,17 +3,19 @@ package safearena
 // Optimized version - remove unused tracking, optimize hot paths
 
 import (
-	"arena"
 	"fmt"
 	"runtime"
 	"sync/atomic"
+	"unsafe"
 )
 
 // Arena wraps Go's arena with lightweight lifetime tracking
 type ArenaOpt struct {
-	inner *arena.Arena
+	inner *backing
 	id    uint64
 	freed atomic.Bool
+	gen   atomic.Uint64  // Bumped by Reset; PtrOpt and SliceOpt capture it
+	stats *statsCounters // nil unless created with NewOptWithStats
 	// Removed: objects sync.Map (never used!)
 }
 
@@ -21,6 +23,7 @@ type ArenaOpt struct {
 type PtrOpt[T any] struct {
 	ptr   *T
 	arena *ArenaOpt
+	gen   uint64 // Arena generation at allocation; costs 8 bytes but makes Reset safe
 	// Removed: arenaID (can get from arena.id if needed)
 }
 
@@ -29,7 +32,7 @@ var arenaCounterOpt atomic.Uint64
 // NewOpt creates a new optimized arena
 func NewOpt() *ArenaOpt {
 	return &ArenaOpt{
-		inner: arena.NewArena(),
+		inner: newBacking(),
 		id:    arenaCounterOpt.Add(1),
 	}
 }
@@ -41,22 +44,59 @@ func AllocOpt[T any](a *ArenaOpt, value T) PtrOpt[T] {
 		panic(fmt.Sprintf("arena %d: allocation after free", a.id))
 	}
 
-	ptr := arena.New[T](a.inner)
+	ptr := backingNew[T](a.inner)
 	*ptr = value
 
-	// No tracking needed!
+	// No tracking needed unless stats were requested
+	if a.stats != nil {
+		a.stats.add(int64(unsafe.Sizeof(value)))
+	}
 
 	return PtrOpt[T]{
 		ptr:   ptr,
 		arena: a,
+		gen:   a.gen.Load(),
+	}
+}
+
+// UnsafeAlloc allocates a value in the arena and returns a raw pointer to it,
+// with no wrapper and no lifetime tracking.
+//
+// WARNING: this is an escape hatch for hot loops that have been measured and
+// proven safe, paralleling SliceOpt.UnsafeGet. Nothing stops the returned
+// pointer from outliving the arena: using it after Free reads or writes
+// released memory, which may crash or silently corrupt unrelated data instead
+// of panicking with "use after free". Keep the pointer local to the function
+// that owns the arena, and run arenacheck, which treats it like a raw
+// arena.New allocation, over any code that uses it. Prefer AllocOpt everywhere
+// else; its Get costs only a freed and generation check.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	for _, v := range input {
+//	    n := safearena.UnsafeAlloc(a, Node{Value: v}) // Never stored beyond a's lifetime
+//	    sum += n.Value
+//	}
+func UnsafeAlloc[T any](a *ArenaOpt, value T) *T {
+	if a.freed.Load() {
+		panic(fmt.Sprintf("arena %d: allocation after free", a.id))
 	}
+
+	ptr := backingNew[T](a.inner)
+	*ptr = value
+	if a.stats != nil {
+		a.stats.add(int64(unsafe.Sizeof(value)))
+	}
+	return ptr
 }
 
 // Get safely dereferences with minimal overhead
 func (p PtrOpt[T]) Get() *T {
-	// Fast path: single atomic load
-	if p.arena.freed.Load() {
-		panic(fmt.Sprintf("arena %d: use after free", p.arena.id))
+	// Fast path: two atomic loads (freed and generation)
+	if p.arena.freed.Load() || p.gen != p.arena.gen.Load() {
+		panic(p.arena.staleError(p.gen))
 	}
 	return p.ptr
 }
@@ -74,6 +114,42 @@ func (a *ArenaOpt) Free() {
 	a.inner.Free()
 }
 
+// Reset releases all allocations and makes the arena ready for reuse, like
+// Arena.Reset. PtrOpt and SliceOpt values allocated before the Reset become
+// invalid and panic with "use after reset" on access; supporting this is why
+// they carry an 8-byte generation. Stats start again from zero, except
+// HighWater, which keeps the peak across Resets.
+//
+// Go arenas cannot be reused once released, so Reset frees the underlying
+// arena and creates a new one: it costs as much as Free followed by NewOpt,
+// and only the ArenaOpt handle is kept.
+//
+// Reset must not be called concurrently with other operations on the arena.
+// OptPool uses it to recycle arenas between units of work.
+//
+// Panics if the arena has already been freed.
+func (a *ArenaOpt) Reset() {
+	if a.freed.Load() {
+		panic(fmt.Sprintf("arena %d: reset after free", a.id))
+	}
+	a.gen.Add(1)
+	a.inner.Free()
+	a.inner = newBacking()
+	if a.stats != nil {
+		a.stats.allocs.Store(0)
+		a.stats.bytes.Store(0)
+	}
+}
+
+// staleError creates the panic message for access to a PtrOpt or SliceOpt
+// from generation gen that is no longer live
+func (a *ArenaOpt) staleError(gen uint64) string {
+	if a.freed.Load() {
+		return fmt.Sprintf("arena %d: use after free", a.id)
+	}
+	return fmt.Sprintf("arena %d: use after reset (allocated in generation %d, arena is at %d)", a.id, gen, a.gen.Load())
+}
+
 // ScopedOpt executes a function with an arena that's automatically freed
 func ScopedOpt[R any](fn func(*ArenaOpt) R) R {
 	a := NewOpt()
@@ -81,7 +157,19 @@ func ScopedOpt[R any](fn func(*ArenaOpt) R) R {
 	return fn(a)
 }
 
-// CloneOpt copies a value out of the arena to the heap
+// ScopedPtrOpt is like ScopedOpt but for functions that return nothing
+func ScopedPtrOpt(fn func(*ArenaOpt)) {
+	a := NewOpt()
+	defer a.Free()
+	fn(a)
+}
+
+// CloneOpt copies a value out of the arena to the heap.
+//
+// The copy is shallow: slice, map, and pointer fields of T still refer to the
+// same memory as the original, so fields that point into the arena dangle
+// after Free. Unlike Clone, it ignores SetDerefDeep; copy such fields
+// explicitly (for example with CloneSliceOpt) before freeing.
 func CloneOpt[T any](p PtrOpt[T]) *T {
 	val := p.Deref()
 	heapCopy := new(T)
@@ -93,6 +181,7 @@ func CloneOpt[T any](p PtrOpt[T]) *T {
 type SliceOpt[T any] struct {
 	slice []T
 	arena *ArenaOpt
+	gen   uint64 // Arena generation at allocation
 }
 
 // AllocSliceOpt allocates a slice in the arena
@@ -101,18 +190,23 @@ func AllocSliceOpt[T any](a *ArenaOpt, size int) SliceOpt[T] {
 		panic(fmt.Sprintf("arena %d: allocation after free", a.id))
 	}
 
-	slice := make([]T, size)
+	slice := backingMakeSlice[T](a.inner, size, size)
+	if a.stats != nil {
+		var zero T
+		a.stats.add(int64(size) * int64(unsafe.Sizeof(zero)))
+	}
 
 	return SliceOpt[T]{
 		slice: slice,
 		arena: a,
+		gen:   a.gen.Load(),
 	}
 }
 
 // Get returns the slice with safety check
 func (s SliceOpt[T]) Get() []T {
-	if s.arena.freed.Load() {
-		panic(fmt.Sprintf("arena %d: use after free", s.arena.id))
+	if s.arena.freed.Load() || s.gen != s.arena.gen.Load() {
+		panic(s.arena.staleError(s.gen))
 	}
 	return s.slice
 }
@@ -122,6 +216,20 @@ func (s SliceOpt[T]) UnsafeGet() []T {
 	return s.slice
 }
 
+// CloneSliceOpt copies an optimized arena slice to the heap, the SliceOpt
+// counterpart of CloneSlice.
+//
+// The copy is shallow: for a SliceOpt[*T], or elements with slice or pointer
+// fields, the references themselves are copied, not the values they point to.
+//
+// Panics if the arena has already been freed.
+func CloneSliceOpt[T any](s SliceOpt[T]) []T {
+	src := s.Get()
+	heapCopy := make([]T, len(src))
+	copy(heapCopy, src)
+	return heapCopy
+}
+
 // SetFinalizer adds a finalizer to detect leaked arenas (optional debug mode)
 func (a *ArenaOpt) SetFinalizer() {
 	runtime.SetFinalizer(a, func(a *ArenaOpt) {
@@ -130,3 +238,35 @@ func (a *ArenaOpt) SetFinalizer() {
 		}
 	})
 }
+
+// NewOptWithFinalizer creates an optimized arena that warns if it is garbage
+// collected without being freed (see SetFinalizer)
+func NewOptWithFinalizer() *ArenaOpt {
+	a := NewOpt()
+	a.SetFinalizer()
+	return a
+}
+
+// StringBuilderOpt is the optimized counterpart of StringBuilder
+type StringBuilderOpt struct {
+	buffers SliceOpt[byte]
+	length  int
+}
+
+// NewStringBuilderOpt creates an arena-allocated StringBuilderOpt with the given capacity
+func NewStringBuilderOpt(a *ArenaOpt, capacity int) PtrOpt[StringBuilderOpt] {
+	return AllocOpt(a, StringBuilderOpt{
+		buffers: AllocSliceOpt[byte](a, capacity),
+		length:  0,
+	})
+}
+
+// Append adds a string to the StringBuilderOpt, growing the buffer if needed
+func (sb *StringBuilderOpt) Append(s string) {
+	sb.buffers.slice, sb.length = appendString(sb.buffers.Get(), sb.length, s, sb.buffers.arena.growBuf)
+}
+
+// String returns the current string content of the StringBuilderOpt
+func (sb *StringBuilderOpt) String() string {
+	return builtString(sb.buffers.Get(), sb.length)
+}
@@ -1,7 +1,11 @@
 package safearena
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
 func TestBasicSafety(t *testing.T) {
@@ -160,3 +164,402 @@ func BenchmarkRegularAlloc(b *testing.B) {
 		}
 	}
 }
+
+func TestCloneSlice(t *testing.T) {
+	a := New()
+
+	s := AllocSlice[int](a, 5)
+	for i := range s.Get() {
+		s.Get()[i] = i * 10
+	}
+	heapCopy := CloneSlice(s)
+
+	a.Free()
+
+	if len(heapCopy) != 5 {
+		t.Fatalf("expected length 5, got %d", len(heapCopy))
+	}
+	for i, v := range heapCopy {
+		if v != i*10 {
+			t.Errorf("index %d: expected %d, got %d", i, i*10, v)
+		}
+	}
+}
+
+func TestCloneInto(t *testing.T) {
+	a := New()
+	p := Alloc(a, "arena data")
+
+	dst := "old"
+	CloneInto(&dst, p)
+	a.Free()
+
+	if dst != "arena data" {
+		t.Errorf("expected arena data, got %q", dst)
+	}
+}
+
+func TestCloneSliceInto(t *testing.T) {
+	a := New()
+	s := AllocSlice[int](a, 3)
+	copy(s.Get(), []int{1, 2, 3})
+
+	tests := []struct {
+		dst  []int
+		want int
+	}{
+		{make([]int, 5), 3},
+		{make([]int, 2), 2},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if n := CloneSliceInto(tt.dst, s); n != tt.want {
+			t.Errorf("len(dst)=%d: expected %d copied, got %d", len(tt.dst), tt.want, n)
+		}
+	}
+
+	dst := make([]int, 3)
+	CloneSliceInto(dst, s)
+	a.Free()
+	if dst[0] != 1 || dst[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", dst)
+	}
+}
+
+func TestCloneSliceShallow(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	x := 1
+	s := AllocSlice[*int](a, 1)
+	s.Get()[0] = &x
+
+	heapCopy := CloneSlice(s)
+	if heapCopy[0] != &x {
+		t.Error("expected pointer elements to be copied, not their pointees")
+	}
+}
+
+func TestCloneSliceAfterFree(t *testing.T) {
+	a := New()
+	s := AllocSlice[byte](a, 8)
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on clone after free")
+		}
+	}()
+	_ = CloneSlice(s)
+}
+
+func TestCloneSliceOpt(t *testing.T) {
+	a := NewOpt()
+
+	s := AllocSliceOpt[int](a, 3)
+	copy(s.Get(), []int{1, 2, 3})
+	heapCopy := CloneSliceOpt(s)
+
+	a.Free()
+
+	if len(heapCopy) != 3 || heapCopy[0] != 1 || heapCopy[2] != 3 {
+		t.Errorf("unexpected copy: %v", heapCopy)
+	}
+}
+
+func TestCloneSliceOptStructs(t *testing.T) {
+	type item struct {
+		ID   int
+		Tags []string // Heap slice: shared by the shallow copy
+	}
+	tags := []string{"a"}
+
+	a := NewOpt()
+	s := AllocSliceOpt[item](a, 2)
+	s.Get()[0] = item{ID: 1, Tags: tags}
+	s.Get()[1] = item{ID: 2}
+	heapCopy := CloneSliceOpt(s)
+	a.Free()
+
+	if len(heapCopy) != 2 || heapCopy[0].ID != 1 || heapCopy[1].ID != 2 {
+		t.Errorf("unexpected copy: %+v", heapCopy)
+	}
+	tags[0] = "b"
+	if heapCopy[0].Tags[0] != "b" {
+		t.Error("expected the copy to share slice fields (shallow copy)")
+	}
+}
+
+func TestCloneSliceOptAfterFree(t *testing.T) {
+	a := NewOpt()
+	s := AllocSliceOpt[int](a, 3)
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic cloning after free")
+		}
+	}()
+	_ = CloneSliceOpt(s)
+}
+
+func TestUnsafeAlloc(t *testing.T) {
+	a := NewOptWithStats()
+	p := UnsafeAlloc(a, int64(7))
+	*p++
+	if *p != 8 {
+		t.Errorf("expected 8, got %d", *p)
+	}
+	if stats := a.Stats(); stats.Allocations != 1 || stats.Bytes != 8 {
+		t.Errorf("expected 1 alloc of 8 bytes, got %+v", stats)
+	}
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "allocation after free") {
+			t.Errorf("expected allocation after free panic, got %q", msg)
+		}
+	}()
+	UnsafeAlloc(a, 1)
+}
+
+func TestSliceData(t *testing.T) {
+	a := New()
+
+	s := AllocSlice[int](a, 8)
+	ptr, n := s.Data()
+
+	if ptr != unsafe.Pointer(&s.Get()[0]) {
+		t.Error("expected Data pointer to match &s.Get()[0]")
+	}
+	if n != 8 {
+		t.Errorf("expected length 8, got %d", n)
+	}
+
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on Data after free")
+		}
+	}()
+	_, _ = s.Data()
+}
+
+func TestSliceDataPtr(t *testing.T) {
+	a := New()
+
+	s := AllocSlice[byte](a, 4096)
+	first := s.DataPtr()
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		if p := s.DataPtr(); p != first {
+			t.Fatalf("expected a stable pointer, got %p then %p", first, p)
+		}
+	}
+	if data, _ := s.Data(); data != first {
+		t.Error("expected DataPtr to match Data")
+	}
+
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") || !strings.Contains(msg, "safearena_test.go") {
+			t.Errorf("expected use after free panic pointing at the test, got %q", msg)
+		}
+	}()
+	s.DataPtr()
+}
+
+func TestSliceAt(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := AllocSlice[int](a, 3)
+	*s.At(1) = 7
+	s.SetAt(2, 9)
+
+	if got := s.Get(); got[1] != 7 || got[2] != 9 {
+		t.Errorf("expected [0 7 9], got %v", got)
+	}
+	if s.Len() != 3 {
+		t.Errorf("expected length 3, got %d", s.Len())
+	}
+}
+
+func TestSliceCopyFill(t *testing.T) {
+	a := New()
+
+	buf := AllocSlice[byte](a, 6)
+	buf.Fill('.')
+	if n := buf.Copy([]byte("abc")); n != 3 {
+		t.Errorf("expected 3 bytes copied, got %d", n)
+	}
+	if got := string(buf.Get()); got != "abc..." {
+		t.Errorf("expected %q, got %q", "abc...", got)
+	}
+	if n := buf.Slice(4, 6).Copy([]byte("xyz")); n != 2 {
+		t.Errorf("expected the copy to stop at the slice length, got %d", n)
+	}
+	if got := string(buf.Get()); got != "abc.xy" {
+		t.Errorf("expected %q, got %q", "abc.xy", got)
+	}
+
+	a.Free()
+	for name, write := range map[string]func(){
+		"Copy": func() { buf.Copy([]byte("abc")) },
+		"Fill": func() { buf.Fill(0) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") || !strings.Contains(msg, "safearena_test.go") {
+					t.Errorf("expected use after free panic pointing at the test, got %q", msg)
+				}
+			}()
+			write()
+		})
+	}
+}
+
+func TestSliceAtOutOfRange(t *testing.T) {
+	a := New()
+	defer a.Free()
+	s := AllocSlice[int](a, 3)
+
+	for name, access := range map[string]func(){
+		"At":       func() { s.At(3) },
+		"negative": func() { s.At(-1) },
+		"SetAt":    func() { s.SetAt(5, 1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "out of range in freed-checked slice") {
+					t.Errorf("expected out of range panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func TestSubSlice(t *testing.T) {
+	a := New()
+	s := AllocSlice[int](a, 5)
+	copy(s.Get(), []int{0, 1, 2, 3, 4})
+
+	sub := s.Slice(1, 3)
+	if got := sub.Get(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+	sub.SetAt(0, 10)
+	if s.Get()[1] != 10 {
+		t.Error("expected sub-slice to share the backing array")
+	}
+
+	for name, bounds := range map[string][2]int{
+		"negative low": {-1, 2},
+		"high < low":   {3, 2},
+		"past cap":     {0, 6},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "slice bounds") {
+					t.Errorf("expected slice bounds panic, got %q", msg)
+				}
+			}()
+			s.Slice(bounds[0], bounds[1])
+		})
+	}
+
+	a.Free()
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic from sub-slice, got %q", msg)
+		}
+	}()
+	sub.Get()
+}
+
+func TestSliceLenAfterFree(t *testing.T) {
+	a := New()
+	s := AllocSlice[int](a, 3)
+	a.Free()
+
+	defer func() {
+		if _, ok := recover().(*ArenaError); !ok {
+			t.Error("expected ArenaError panic on Len after free")
+		}
+	}()
+	s.Len()
+}
+
+func TestCloneAll(t *testing.T) {
+	a := New()
+	ps := make([]Ptr[int], 100)
+	for i := range ps {
+		ps[i] = Alloc(a, i)
+	}
+
+	values := CloneAll(ps)
+	ptrs := ClonePtrs(ps)
+	a.Free()
+
+	for i := range ps {
+		if values[i] != i || *ptrs[i] != i {
+			t.Fatalf("index %d: expected %d, got %d and %d", i, i, values[i], *ptrs[i])
+		}
+	}
+	if len(CloneAll[int](nil)) != 0 {
+		t.Error("expected empty result for no pointers")
+	}
+}
+
+func TestCloneAllStale(t *testing.T) {
+	live, freed := New(), New()
+	defer live.Free()
+	ps := []Ptr[int]{Alloc(live, 1), Alloc(freed, 2), Alloc(freed, 3)}
+	freed.Free()
+
+	for name, clone := range map[string]func(){
+		"CloneAll":  func() { CloneAll(ps) },
+		"ClonePtrs": func() { ClonePtrs(ps) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(*ArenaError)
+				if !ok || err.Kind != CloneAfterFree {
+					t.Fatalf("expected CloneAfterFree panic, got %v", err)
+				}
+				if msg := err.Error(); !strings.Contains(msg, name+"() called with 2 of 3 values stale (first at index 1)") {
+					t.Errorf("expected combined stale count, got %q", msg)
+				}
+			}()
+			clone()
+		})
+	}
+}
+
+func TestPtrSize(t *testing.T) {
+	type big struct{ data [1024]byte }
+
+	sizes := []struct {
+		name      string
+		got, want uintptr
+	}{
+		{"Ptr[int]", unsafe.Sizeof(Ptr[int]{}), ptrSize},
+		{"Ptr[big]", unsafe.Sizeof(Ptr[big]{}), ptrSize},
+		{"Slice[int]", unsafe.Sizeof(Slice[int]{}), sliceSize},
+		{"Slice[big]", unsafe.Sizeof(Slice[big]{}), sliceSize},
+	}
+	for _, s := range sizes {
+		if s.got != s.want {
+			t.Errorf("unsafe.Sizeof(%s) = %d, want %d", s.name, s.got, s.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package safearena
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// ArenaScanner is the arena analogue of bufio.Scanner: it reads records
+// (lines by default) from a stream and copies each into arena storage, so a
+// whole batch of tokens is processed without garbage and released at once by
+// Free. Tokens are lifetime-checked Slice[byte] values.
+//
+// Every method panics if the arena has been freed or reset.
+// An ArenaScanner is not safe for concurrent use.
+//
+// Example:
+//
+//	sc := safearena.NewScanner(a, logFile)
+//	for sc.Scan() {
+//	    process(sc.Token())
+//	}
+//	if err := sc.Err(); err != nil {
+//	    return err
+//	}
+type ArenaScanner struct {
+	arena *Arena
+	gen   uint64 // Arena generation the tokens belong to
+	sc    *bufio.Scanner
+	reuse bool   // Tokens share storage; see ReuseStorage
+	buf   []byte // Token storage reused when reuse is set
+	token Slice[byte]
+}
+
+// NewScanner returns a scanner reading newline-delimited records from r, as
+// with bufio.ScanLines. Use Split for other record formats.
+//
+// Panics if the arena has already been freed.
+func NewScanner(a *Arena, r io.Reader) *ArenaScanner {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return &ArenaScanner{
+		arena: a,
+		gen:   a.gen.Load(),
+		sc:    bufio.NewScanner(r),
+	}
+}
+
+// Split sets the split function, as bufio.Scanner.Split does; ScanLines,
+// ScanWords, and ScanLengthPrefixed all work. It must be called before Scan.
+func (s *ArenaScanner) Split(split bufio.SplitFunc) {
+	s.sc.Split(split)
+}
+
+// Buffer sets the maximum token size, as bufio.Scanner.Buffer does, with the
+// read buffer starting at initial bytes. It must be called before Scan.
+func (s *ArenaScanner) Buffer(initial, maxSize int) {
+	s.sc.Buffer(make([]byte, 0, initial), maxSize)
+}
+
+// ReuseStorage makes every token share one arena buffer, grown as needed,
+// instead of each getting its own copy. It bounds arena use to the largest
+// token rather than the whole stream, at the cost that a token's contents
+// are only valid until the next call to Scan, as with bufio.Scanner.Bytes.
+// Copy (or Clone) anything that must outlive the current record.
+func (s *ArenaScanner) ReuseStorage(reuse bool) {
+	s.reuse = reuse
+}
+
+// Scan advances to the next record, copying it into the arena, and reports
+// whether there was one. It returns false at the end of the input or on an
+// error; Err distinguishes the two. A final record without a trailing
+// newline is returned like any other; with ScanLengthPrefixed, a truncated
+// final record is an io.ErrUnexpectedEOF error.
+func (s *ArenaScanner) Scan() bool {
+	s.check()
+	if !s.sc.Scan() {
+		s.token = Slice[byte]{}
+		return false
+	}
+
+	raw := s.sc.Bytes()
+	var storage []byte
+	if s.reuse {
+		if len(raw) > cap(s.buf) {
+			s.buf = s.arena.makeBytes(0, max(len(raw), 2*cap(s.buf)))
+		}
+		storage = s.buf[:len(raw)]
+	} else {
+		storage = s.arena.makeBytes(len(raw), len(raw))
+	}
+	copy(storage, raw)
+	s.token = Slice[byte]{slice: storage, arena: s.arena, gen: s.gen}
+	return true
+}
+
+// Token returns the most recent record read by Scan, without its delimiter.
+// With ReuseStorage, its contents are overwritten by the next Scan.
+func (s *ArenaScanner) Token() Slice[byte] {
+	s.check()
+	return s.token
+}
+
+// Err returns the first error other than io.EOF encountered by the scanner
+func (s *ArenaScanner) Err() error {
+	return s.sc.Err()
+}
+
+// check panics if the scanner's arena has been freed or reset
+func (s *ArenaScanner) check() {
+	if s.arena.freed.Load() || !s.arena.live(s.gen) {
+		stack := captureStack(3)
+		panic(s.arena.staleError(s.gen, stack, nil))
+	}
+}
+
+// ScanLengthPrefixed is a split function for records framed by a 4-byte
+// big-endian length followed by that many bytes of payload; tokens are the
+// payloads. Input that ends partway through a record is an
+// io.ErrUnexpectedEOF error rather than a silently dropped record.
+func ScanLengthPrefixed(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) >= 4 {
+		n := uint64(binary.BigEndian.Uint32(data))
+		if uint64(len(data)-4) >= n {
+			return 4 + int(n), data[4 : 4+n], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	return 0, nil, nil // Request more data
+}
@@ -0,0 +1,117 @@
+package safearena
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestScannerLines(t *testing.T) {
+	a := New()
+	sc := NewScanner(a, strings.NewReader("alpha\nbeta\n\ngamma")) // Partial last record
+
+	var tokens []Slice[byte]
+	for sc.Scan() {
+		tokens = append(tokens, sc.Token())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"alpha", "beta", "", "gamma"}
+	if len(tokens) != len(want) {
+		t.Fatalf("expected %d tokens, got %d", len(want), len(tokens))
+	}
+	for i, tok := range tokens {
+		if got := string(tok.Get()); got != want[i] {
+			t.Errorf("token %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+	if a.Stats().Bytes != int64(len("alphabetagamma")) {
+		t.Errorf("expected tokens to be copied into the arena, got %d bytes", a.Stats().Bytes)
+	}
+
+	a.Free()
+	for name, access := range map[string]func(){
+		"token": func() { tokens[0].Get() },
+		"Scan":  func() { sc.Scan() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func TestScannerReuseStorage(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	var input strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&input, "%04d\n", i)
+	}
+	sc := NewScanner(a, strings.NewReader(input.String()))
+	sc.ReuseStorage(true)
+
+	var first Slice[byte]
+	n := 0
+	for sc.Scan() {
+		if n == 0 {
+			first = sc.Token()
+		}
+		n++
+	}
+	if n != 1000 {
+		t.Fatalf("expected 1000 records, got %d", n)
+	}
+	if a.Stats().Bytes > 64 {
+		t.Errorf("expected storage bounded by the largest token, got %d bytes", a.Stats().Bytes)
+	}
+	if got := string(first.Get()); got != "0999" {
+		t.Errorf("expected shared storage to hold the last record, got %q", got)
+	}
+}
+
+func TestScannerLengthPrefixed(t *testing.T) {
+	var stream bytes.Buffer
+	for _, rec := range []string{"first", "", "third record"} {
+		stream.Write(binary.BigEndian.AppendUint32(nil, uint32(len(rec))))
+		stream.WriteString(rec)
+	}
+	complete := stream.Len()
+	stream.Write([]byte{0, 0, 0, 9, 'p', 'a', 'r'}) // Truncated final record
+
+	a := New()
+	defer a.Free()
+	sc := NewScanner(a, bytes.NewReader(stream.Bytes()))
+	sc.Split(ScanLengthPrefixed)
+
+	var got []string
+	for sc.Scan() {
+		got = append(got, string(sc.Token().Get()))
+	}
+	if strings.Join(got, "|") != "first||third record" {
+		t.Errorf("unexpected records %q", got)
+	}
+	if !errors.Is(sc.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF for the truncated record, got %v", sc.Err())
+	}
+
+	sc = NewScanner(a, bytes.NewReader(stream.Bytes()[:complete]))
+	sc.Split(ScanLengthPrefixed)
+	for sc.Scan() {
+	}
+	if sc.Err() != nil {
+		t.Errorf("expected clean EOF, got %v", sc.Err())
+	}
+}
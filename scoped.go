@@ -0,0 +1,232 @@
+package safearena
+
+import (
+	"context"
+)
+
+// ScopedContext is like Scoped but threads a context through to fn, tying the
+// arena scope to a request's lifetime. The arena is freed when fn returns,
+// including when fn panics (the panic is propagated after Free).
+//
+// ScopedContext does not cancel fn; check ctx.Err() inside fn to stop early.
+//
+// Example:
+//
+//	func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//	    resp := safearena.ScopedContext(r.Context(), func(ctx context.Context, a *safearena.Arena) Response {
+//	        buf := safearena.AllocSlice[byte](a, 4096)
+//	        if err := ctx.Err(); err != nil {
+//	            return Response{Status: 503}
+//	        }
+//	        return process(ctx, buf.Get())
+//	    })
+//	    writeResponse(w, resp)
+//	}
+func ScopedContext[R any](ctx context.Context, fn func(context.Context, *Arena) R) R {
+	a := New()
+	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	return fn(ctx, a)
+}
+
+// ScopedErr is like Scoped for functions that can fail. It returns fn's result
+// and error directly, freeing the arena on both paths (and when fn panics).
+//
+// As with Scoped, the result must not reference arena memory.
+//
+// Example:
+//
+//	resp, err := safearena.ScopedErr(func(a *safearena.Arena) (Response, error) {
+//	    req, err := safearena.UnmarshalArena[Request](a, body)
+//	    if err != nil {
+//	        return Response{}, err // Arena is still freed
+//	    }
+//	    return handle(req.Get()), nil
+//	})
+func ScopedErr[R any](fn func(*Arena) (R, error)) (R, error) {
+	a := New()
+	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	return fn(a)
+}
+
+// ScopedResult is like Scoped but splits the callback in two: build does the
+// arena work, and extract, called after build and before the arena is freed,
+// produces the heap result. Keeping result extraction in its own function,
+// with no arena parameter, makes the hand-off to the heap explicit and
+// reviewable, and makes it harder to return a Ptr by accident than with a
+// single callback that both allocates and returns.
+//
+// build and extract typically share state through captured variables, which
+// must not be used after ScopedResult returns. As with Scoped, the result
+// must not reference arena memory; Clone, CloneSlice, and Deref produce heap
+// copies.
+//
+// Example:
+//
+//	var nodes safearena.PtrSlice[Node]
+//	total := safearena.ScopedResult(
+//	    func(a *safearena.Arena) {
+//	        for _, rec := range records {
+//	            nodes.Append(a, parse(rec))
+//	        }
+//	    },
+//	    func() int {
+//	        sum := 0
+//	        for i := 0; i < nodes.Len(); i++ {
+//	            sum += nodes.At(i).Get().Weight
+//	        }
+//	        return sum
+//	    },
+//	)
+func ScopedResult[R any](build func(*Arena), extract func() R) R {
+	a := New()
+	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	build(a)
+	return extract()
+}
+
+// ScopedStats is like Scoped but also returns the arena's Stats, captured
+// just before the arena is freed, for ad-hoc profiling without installing
+// hooks or an observer.
+//
+// As with Scoped, the result must not reference arena memory.
+//
+// Example:
+//
+//	resp, stats := safearena.ScopedStats(func(a *safearena.Arena) Response {
+//	    return handle(a, req)
+//	})
+//	log.Printf("request used %dKB across %d allocations", stats.Bytes>>10, stats.Allocations)
+func ScopedStats[R any](fn func(*Arena) R) (R, Stats) {
+	a := New()
+	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	result := fn(a)
+	return result, a.Stats()
+}
+
+// SafeScoped is like Scoped but turns arena safety violations into errors:
+// if fn panics with an *ArenaError, such as a use after free or an exceeded
+// budget, SafeScoped returns the zero R and that error instead of panicking.
+// Freeing the scope's arena inside fn is reported as a DoubleFree error, as
+// Scoped would panic. Other panics are not arena violations and propagate
+// unchanged, after the arena is freed.
+//
+// Use errors.As to inspect the violation:
+//
+//	resp, err := safearena.SafeScoped(func(a *safearena.Arena) Response {
+//	    return handle(a, req)
+//	})
+//	var ae *safearena.ArenaError
+//	if errors.As(err, &ae) {
+//	    log.Printf("arena violation (%v): %v", ae.Kind, ae)
+//	}
+func SafeScoped[R any](fn func(*Arena) R) (result R, err error) {
+	a := New()
+	defer func() {
+		r := recover()
+		if !a.TryFree() && r == nil {
+			r = errorWithHint(a, "double free", captureStack(2), DoubleFree)
+		}
+		if r == nil {
+			return
+		}
+		ae, ok := r.(*ArenaError)
+		if !ok {
+			panic(r)
+		}
+		var zero R
+		result, err = zero, ae
+	}()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	return fn(a), nil
+}
+
+// ScopedAuto is like Scoped but lets the caller pick the arena implementation
+// with a hint: HintDebug uses the safe Arena for its diagnostics, HintHot uses
+// the optimized ArenaOpt. fn receives the arena as an AnyArena, so the same
+// code runs on either implementation. The arena is freed when fn returns.
+//
+// Panics if hint is not a known Hint value.
+//
+// Example:
+//
+//	hint := safearena.HintDebug
+//	if hotPath {
+//	    hint = safearena.HintHot
+//	}
+//	n := safearena.ScopedAuto(hint, func(a safearena.AnyArena) int {
+//	    buf := a.AllocBytes(4096)
+//	    return fill(buf)
+//	})
+func ScopedAuto[R any](hint Hint, fn func(AnyArena) R) R {
+	a, free := newAnyArena(hint)
+	defer free()
+	return fn(a)
+}
+
+// ScopedConsume creates an arena, runs produce to build a value that may
+// reference arena memory, and passes it to consume while the arena is still
+// alive. The arena is freed after consume returns, so consume can read arena
+// data directly without cloning it to the heap.
+//
+// consume must not retain the value or anything it references.
+//
+// Example:
+//
+//	safearena.ScopedConsume(
+//	    func(a *safearena.Arena) Frame { return decode(a, packet) },
+//	    func(f Frame) { render(f.Pixels.Get()) },
+//	)
+func ScopedConsume[T any](produce func(*Arena) T, consume func(T)) {
+	a := New()
+	defer a.Free()
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	consume(produce(a))
+}
+
+// WithArena runs fn with a caller-owned arena and returns its result. Unlike
+// Scoped it does not free the arena, so code written in the Scoped style can
+// run on an arena the caller manages, for example one reused across requests
+// with Reset. If fn panics, the panic propagates and the arena is left as is.
+//
+// As with Scoped, the result must not reference arena memory unless the
+// caller keeps the arena alive for as long as the result is used.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	a := safearena.New()
+//	defer a.Free()
+//	for _, req := range requests {
+//	    resp := safearena.WithArena(a, func(a *safearena.Arena) Response {
+//	        return handle(a, req)
+//	    })
+//	    send(resp)
+//	    a.Reset()
+//	}
+func WithArena[R any](a *Arena, fn func(*Arena) R) R {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "WithArena after free", stack, AllocAfterFree))
+	}
+	if a.debug != nil {
+		defer enterScope(a)()
+	}
+	return fn(a)
+}
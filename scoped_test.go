@@ -0,0 +1,355 @@
+package safearena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func TestScopedContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-1")
+
+	var arena *Arena
+	result := ScopedContext(ctx, func(ctx context.Context, a *Arena) string {
+		arena = a
+		p := Alloc(a, ctx.Value(ctxKey{}).(string))
+		return p.Deref()
+	})
+
+	if result != "request-1" {
+		t.Errorf("expected request-1, got %s", result)
+	}
+	if !arena.freed.Load() {
+		t.Error("expected arena to be freed after ScopedContext returns")
+	}
+}
+
+func TestScopedContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := ScopedContext(ctx, func(ctx context.Context, a *Arena) error {
+		return ctx.Err()
+	})
+
+	if result != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", result)
+	}
+}
+
+func TestScopedContextPanic(t *testing.T) {
+	var arena *Arena
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected panic to propagate, got %v", r)
+		}
+		if !arena.freed.Load() {
+			t.Error("expected arena to be freed when fn panics")
+		}
+	}()
+
+	ScopedContext(context.Background(), func(ctx context.Context, a *Arena) int {
+		arena = a
+		panic("boom")
+	})
+}
+
+func TestScopedErr(t *testing.T) {
+	errBad := errors.New("bad input")
+
+	tests := []struct {
+		name    string
+		fail    bool
+		want    int
+		wantErr error
+	}{
+		{"success", false, 42, nil},
+		{"error", true, 0, errBad},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arena *Arena
+			got, err := ScopedErr(func(a *Arena) (int, error) {
+				arena = a
+				p := Alloc(a, 42)
+				if tt.fail {
+					return 0, errBad
+				}
+				return p.Deref(), nil
+			})
+
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("expected (%d, %v), got (%d, %v)", tt.want, tt.wantErr, got, err)
+			}
+			if !arena.IsFreed() {
+				t.Error("expected arena to be freed after ScopedErr returns")
+			}
+		})
+	}
+}
+
+func TestScopedErrPanic(t *testing.T) {
+	var arena *Arena
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected panic to propagate, got %v", r)
+		}
+		if !arena.IsFreed() {
+			t.Error("expected arena to be freed when fn panics")
+		}
+	}()
+
+	ScopedErr(func(a *Arena) (int, error) {
+		arena = a
+		panic("boom")
+	})
+}
+
+func TestScopedResult(t *testing.T) {
+	var arena *Arena
+	var values Slice[int]
+	var order []string
+
+	sum := ScopedResult(
+		func(a *Arena) {
+			order = append(order, "build")
+			arena = a
+			values = AllocSlice[int](a, 4)
+			for i := range values.Get() {
+				values.Get()[i] = i + 1
+			}
+		},
+		func() int {
+			order = append(order, "extract")
+			if arena.IsFreed() {
+				t.Error("expected extract to run before Free")
+			}
+			sum := 0
+			for _, v := range values.Get() {
+				sum += v
+			}
+			return sum
+		},
+	)
+
+	if sum != 10 {
+		t.Errorf("expected 10, got %d", sum)
+	}
+	if fmt.Sprint(order) != "[build extract]" {
+		t.Errorf("unexpected call order %v", order)
+	}
+	if !arena.IsFreed() || values.Valid() {
+		t.Error("expected the arena to be freed after extraction")
+	}
+}
+
+func TestScopedStats(t *testing.T) {
+	var arena *Arena
+	sum, stats := ScopedStats(func(a *Arena) int {
+		arena = a
+		s := AllocSlice[int64](a, 10)
+		for i := range s.Get() {
+			s.Get()[i] = int64(i)
+		}
+		total := Alloc(a, int64(0))
+		for _, v := range s.Get() {
+			*total.Get() += v
+		}
+		return int(total.Deref())
+	})
+
+	if sum != 45 {
+		t.Errorf("expected 45, got %d", sum)
+	}
+	if stats.Allocations != 2 || stats.Bytes != 88 {
+		t.Errorf("expected 2 allocations and 88 bytes, got %+v", stats)
+	}
+	if !arena.IsFreed() {
+		t.Error("expected the arena to be freed")
+	}
+}
+
+func TestSafeScoped(t *testing.T) {
+	n, err := SafeScoped(func(a *Arena) int { return Alloc(a, 7).Deref() })
+	if n != 7 || err != nil {
+		t.Errorf("expected 7 and no error, got %d, %v", n, err)
+	}
+
+	var escaped Ptr[int]
+	n, err = SafeScoped(func(a *Arena) int {
+		escaped = Alloc(a, 1)
+		a.Free()
+		return escaped.Deref() // Use after free inside fn
+	})
+	var ae *ArenaError
+	if !errors.As(err, &ae) || ae.Kind != UseAfterFree {
+		t.Fatalf("expected UseAfterFree error, got %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected zero result, got %d", n)
+	}
+
+	_, err = SafeScoped(func(a *Arena) int {
+		a.Free()
+		return 0
+	})
+	if !errors.As(err, &ae) || ae.Kind != DoubleFree {
+		t.Errorf("expected DoubleFree error, got %v", err)
+	}
+}
+
+func TestSafeScopedOtherPanic(t *testing.T) {
+	var arena *Arena
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected the unrelated panic to propagate, got %v", r)
+		}
+		if !arena.IsFreed() {
+			t.Error("expected the arena to be freed")
+		}
+	}()
+	_, _ = SafeScoped(func(a *Arena) int {
+		arena = a
+		panic("boom")
+	})
+}
+
+func TestScopedAuto(t *testing.T) {
+	tests := []struct {
+		hint Hint
+		want string // concrete arena type
+	}{
+		{HintDebug, "*safearena.Arena"},
+		{HintHot, "*safearena.ArenaOpt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hint.String(), func(t *testing.T) {
+			var arena AnyArena
+			n := ScopedAuto(tt.hint, func(a AnyArena) int {
+				arena = a
+				buf := a.AllocBytes(5)
+				return copy(buf, "hello")
+			})
+
+			if n != 5 {
+				t.Errorf("expected 5 bytes copied, got %d", n)
+			}
+			if got := fmt.Sprintf("%T", arena); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+			if !arena.IsFreed() {
+				t.Error("expected arena to be freed after ScopedAuto returns")
+			}
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "allocation after free") {
+					t.Errorf("expected allocation after free panic, got %q", msg)
+				}
+			}()
+			arena.AllocBytes(1)
+		})
+	}
+}
+
+func TestScopedAutoUnknownHint(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for unknown hint")
+		}
+	}()
+	ScopedAuto(Hint(99), func(a AnyArena) int { return 0 })
+}
+
+func TestAnyArenaAllocBytesZeroed(t *testing.T) {
+	for _, hint := range []Hint{HintDebug, HintHot} {
+		ScopedAuto(hint, func(a AnyArena) struct{} {
+			for i, b := range a.AllocBytes(64) {
+				if b != 0 {
+					t.Errorf("%v: expected zeroed byte at %d, got %d", hint, i, b)
+				}
+			}
+			return struct{}{}
+		})
+	}
+}
+
+func TestScopedConsume(t *testing.T) {
+	type frame struct {
+		Width  int
+		Pixels Slice[byte]
+	}
+
+	var arena *Arena
+	var pixels Slice[byte]
+	consumed := false
+
+	ScopedConsume(
+		func(a *Arena) frame {
+			arena = a
+			px := AllocSlice[byte](a, 4)
+			copy(px.Get(), "rgba")
+			return frame{Width: 1, Pixels: px}
+		},
+		func(f frame) {
+			consumed = true
+			if got := string(f.Pixels.Get()); got != "rgba" {
+				t.Errorf("expected rgba, got %q", got)
+			}
+			if arena.freed.Load() {
+				t.Error("expected arena to be alive during consume")
+			}
+			pixels = f.Pixels
+		},
+	)
+
+	if !consumed {
+		t.Fatal("consume was not called")
+	}
+	if !arena.freed.Load() {
+		t.Error("expected arena to be freed after consume returns")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic accessing retained arena data after ScopedConsume")
+		}
+	}()
+	_ = pixels.Get()
+}
+
+func TestWithArena(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	p := WithArena(a, func(a *Arena) Ptr[int] {
+		return Alloc(a, 42)
+	})
+	if a.IsFreed() {
+		t.Fatal("expected WithArena to leave the arena alive")
+	}
+	if *p.Get() != 42 {
+		t.Errorf("expected 42, got %d", *p.Get())
+	}
+}
+
+func TestWithArenaAfterFree(t *testing.T) {
+	a := New()
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "WithArena after free") {
+			t.Errorf("expected WithArena after free panic, got %q", msg)
+		}
+	}()
+	WithArena(a, func(*Arena) int { return 0 })
+}
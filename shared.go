@@ -0,0 +1,80 @@
+package safearena
+
+import (
+	"sync"
+)
+
+// SharedArena is an Arena that several goroutines can allocate from at once.
+// Allocation is serialized by a mutex; the Ptr and Slice values it returns are
+// ordinary arena values, so Get and Deref stay lock-free (atomic freed and
+// generation checks).
+//
+// The mutex makes every allocation contend with every other: with many
+// goroutines allocating small values in a tight loop, throughput can drop
+// below that of one arena per goroutine. Prefer separate arenas when work can
+// be partitioned, and use SharedArena when results must share one lifetime.
+//
+// Free must not race with goroutines that still read values from the arena.
+type SharedArena struct {
+	mu    sync.Mutex
+	arena *Arena
+}
+
+// NewShared creates a new SharedArena.
+//
+// Example:
+//
+//	s := safearena.NewShared()
+//	defer s.Free()
+//	var wg sync.WaitGroup
+//	for _, job := range jobs {
+//	    wg.Add(1)
+//	    go func() {
+//	        defer wg.Done()
+//	        results <- safearena.SharedAlloc(s, process(job))
+//	    }()
+//	}
+func NewShared() *SharedArena {
+	return &SharedArena{arena: New()}
+}
+
+// SharedAlloc is like Alloc for a SharedArena and is safe for concurrent use.
+//
+// Panics if the arena has already been freed.
+func SharedAlloc[T any](s *SharedArena, value T) Ptr[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.arena.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(s.arena, "allocation after free", stack, AllocAfterFree))
+	}
+	return Alloc(s.arena, value)
+}
+
+// SharedAllocSlice is like AllocSlice for a SharedArena and is safe for
+// concurrent use.
+//
+// Panics if the arena has already been freed.
+func SharedAllocSlice[T any](s *SharedArena, size int) Slice[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.arena.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(s.arena, "allocation after free", stack, AllocAfterFree))
+	}
+	return AllocSlice[T](s.arena, size)
+}
+
+// Stats returns the underlying arena's allocation statistics
+func (s *SharedArena) Stats() Stats {
+	return s.arena.Stats()
+}
+
+// Free frees the arena, waiting for in-flight allocations to finish.
+//
+// Panics on double free.
+func (s *SharedArena) Free() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.arena.Free()
+}
@@ -0,0 +1,82 @@
+package safearena
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSharedArenaConcurrent(t *testing.T) {
+	s := NewShared()
+	defer s.Free()
+
+	const (
+		workers = 16
+		perG    = 1000
+	)
+
+	type record struct {
+		Worker, Seq int
+		Payload     Slice[int]
+	}
+
+	results := make([][]Ptr[record], workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perG; i++ {
+				payload := SharedAllocSlice[int](s, 4)
+				for j := range payload.Get() {
+					payload.Get()[j] = w*perG + i
+				}
+				results[w] = append(results[w], SharedAlloc(s, record{Worker: w, Seq: i, Payload: payload}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for w, ptrs := range results {
+		for i, p := range ptrs {
+			r := p.Get()
+			if r.Worker != w || r.Seq != i {
+				t.Fatalf("corrupted record: expected (%d, %d), got (%d, %d)", w, i, r.Worker, r.Seq)
+			}
+			for _, v := range r.Payload.Get() {
+				if v != w*perG+i {
+					t.Fatalf("corrupted payload for (%d, %d): got %d", w, i, v)
+				}
+			}
+		}
+	}
+
+	if got := s.Stats().Allocations; got != 2*workers*perG {
+		t.Errorf("expected %d allocations, got %d", 2*workers*perG, got)
+	}
+}
+
+func TestSharedArenaAfterFree(t *testing.T) {
+	s := NewShared()
+	p := SharedAlloc(s, 42)
+	s.Free()
+
+	tests := []struct {
+		name string
+		use  func()
+	}{
+		{"get", func() { _ = p.Get() }},
+		{"alloc", func() { SharedAlloc(s, 1) }},
+		{"alloc slice", func() { SharedAllocSlice[int](s, 1) }},
+		{"double free", func() { s.Free() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.use()
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package safearena
+
+import "fmt"
+
+// Slice2D is a [][]T whose outer slice and rows all live in the arena: the
+// rows share one contiguous backing array, and the outer slice of row headers
+// is a second arena allocation. It replaces building [][]T from one
+// AllocSlice per row plus a heap-allocated outer slice.
+//
+// Unlike Grid, Slice2D exposes the [][]T itself (see Get) for code written
+// against nested slices. Each row's capacity ends at the row, so appending to
+// a row moves it to the heap instead of overwriting the next row.
+//
+// Every method panics if the arena has been freed or reset.
+//
+// Example:
+//
+//	bufs := safearena.AllocSlice2D[byte](a, 100, 1024)
+//	for i := 0; i < bufs.Rows(); i++ {
+//	    n, _ := r.Read(bufs.Row(i).Get())
+//	    handle(bufs.Row(i).Slice(0, n))
+//	}
+type Slice2D[T any] struct {
+	arena *Arena
+	gen   uint64 // Arena generation the rows belong to
+	rows  [][]T  // Arena-backed headers into one arena-backed array
+}
+
+// AllocSlice2D allocates rows zeroed rows of cols elements each, in two
+// arena allocations.
+//
+// Panics if rows or cols is negative, or if the arena has already been freed.
+func AllocSlice2D[T any](a *Arena, rows, cols int) Slice2D[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("safearena: negative Slice2D dimensions %dx%d", rows, cols))
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	cells := makeSlice[T](a, rows*cols, rows*cols)
+	headers := makeSlice[[]T](a, rows, rows)
+	for i := range headers {
+		headers[i] = cells[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return Slice2D[T]{arena: a, gen: a.gen.Load(), rows: headers}
+}
+
+// Row returns row i as a lifetime-checked slice.
+//
+// Panics if i is out of range.
+func (s Slice2D[T]) Row(i int) Slice[T] {
+	s.check()
+	if i < 0 || i >= len(s.rows) {
+		panic(fmt.Sprintf("safearena: Slice2D row %d out of range with %d rows", i, len(s.rows)))
+	}
+	return Slice[T]{slice: s.rows[i], arena: s.arena, gen: s.gen}
+}
+
+// Rows returns the number of rows
+func (s Slice2D[T]) Rows() int {
+	s.check()
+	return len(s.rows)
+}
+
+// Get returns the underlying [][]T. Like Slice.Get, neither it nor its rows
+// may be used past the arena's lifetime.
+func (s Slice2D[T]) Get() [][]T {
+	s.check()
+	return s.rows
+}
+
+// check panics if the slice's arena has been freed or reset
+func (s Slice2D[T]) check() {
+	if s.arena.freed.Load() || !s.arena.live(s.gen) {
+		stack := captureStack(3)
+		panic(s.arena.staleError(s.gen, stack, nil))
+	}
+}
@@ -0,0 +1,101 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestAllocSlice2D(t *testing.T) {
+	enableDebug(t) // For Contains
+
+	a := New()
+
+	s := AllocSlice2D[byte](a, 3, 4)
+	if s.Rows() != 3 {
+		t.Fatalf("expected 3 rows, got %d", s.Rows())
+	}
+	for i := 0; i < s.Rows(); i++ {
+		s.Row(i).Fill(byte('a' + i))
+	}
+	rows := s.Get()
+	rows[1][0] = 'X'
+	if got := fmt.Sprintf("%s", rows); got != "[aaaa Xbbb cccc]" {
+		t.Errorf("unexpected rows %s", got)
+	}
+	if cap(rows[0]) != 4 {
+		t.Errorf("expected row capacity to end at the row, got %d", cap(rows[0]))
+	}
+	if !a.Contains(unsafe.Pointer(&rows[0])) || !a.Contains(unsafe.Pointer(&rows[2][3])) {
+		t.Error("expected both the row headers and the rows to be arena-resident")
+	}
+	if stats := a.Stats(); stats.Allocations != 2 {
+		t.Errorf("expected 2 allocations, got %+v", stats)
+	}
+
+	row := s.Row(0)
+	a.Free()
+	for name, access := range map[string]func(){
+		"Row":  func() { s.Row(0) },
+		"Rows": func() { s.Rows() },
+		"Get":  func() { s.Get() },
+		"row":  func() { row.Get() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			access()
+		})
+	}
+}
+
+func TestAllocSlice2DBounds(t *testing.T) {
+	a := New()
+	defer a.Free()
+	s := AllocSlice2D[int](a, 2, 2)
+
+	for name, tc := range map[string]struct {
+		access func()
+		want   string
+	}{
+		"row past end":  {func() { s.Row(2) }, "Slice2D row 2 out of range with 2 rows"},
+		"negative row":  {func() { s.Row(-1) }, "Slice2D row -1 out of range"},
+		"negative size": {func() { AllocSlice2D[int](a, 2, -1) }, "negative Slice2D dimensions 2x-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.access()
+		})
+	}
+}
+
+func BenchmarkAllocSlice2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		a := New()
+		rows := AllocSlice2D[byte](a, 100, 1024).Get()
+		for _, row := range rows {
+			row[0] = 1
+		}
+		a.Free()
+	}
+}
+
+func BenchmarkHeapSlice2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		rows := make([][]byte, 100)
+		for j := range rows {
+			rows[j] = make([]byte, 1024)
+			rows[j][0] = 1
+		}
+	}
+}
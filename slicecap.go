@@ -0,0 +1,139 @@
+package safearena
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// AllocSliceCap allocates a slice with separate length and capacity in the
+// arena, like make([]T, length, capacity). Get returns the first length
+// elements; AppendSlice fills the remaining capacity without reallocating.
+//
+// Panics if capacity < length, if length is negative, or if the arena has
+// been freed.
+//
+// Example:
+//
+//	ids := safearena.AllocSliceCap[int](a, 0, 1024)
+//	for _, row := range rows {
+//	    ids = safearena.AppendSlice(ids, row.ID)
+//	}
+func AllocSliceCap[T any](a *Arena, length, capacity int) Slice[T] {
+	if a.debug != nil {
+		defer recordTiming(opAllocSlice, time.Now())
+	}
+	if length < 0 || capacity < length {
+		panic(fmt.Sprintf("safearena: invalid slice length %d and capacity %d", length, capacity))
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	return Slice[T]{
+		slice: makeSlice[T](a, length, capacity),
+		arena: a,
+		gen:   a.gen.Load(),
+	}
+}
+
+// AppendSlice appends values to s and returns the updated Slice, like the
+// append builtin. Values are written into s's spare capacity when they fit
+// and none of it was moved out by TakeBytes through another view; otherwise
+// the elements are moved to a larger allocation in the same arena, and the
+// old storage stays allocated until the arena is freed.
+//
+// As with append, always use the returned Slice.
+//
+// Panics if the arena has been freed or reset since s was allocated, or if s
+// was taken with TakeBytes.
+//
+// Example:
+//
+//	buf := safearena.AllocSliceCap[byte](a, 0, 64)
+//	buf = safearena.AppendSlice(buf, 'o', 'k')
+func AppendSlice[T any](s Slice[T], values ...T) Slice[T] {
+	a := s.arena
+	if a.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := a.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(a.staleError(s.gen, stack, site))
+	}
+
+	n := len(s.slice)
+	if n+len(values) > cap(s.slice) || !s.spareLive(len(values)) {
+		grown := makeSlice[T](a, n, max(2*cap(s.slice), n+len(values)))
+		copy(grown, s.slice)
+		s.slice = grown
+	}
+	s.slice = append(s.slice, values...) // Fits in capacity; stays in the arena
+	return s
+}
+
+// spareLive reports whether none of the first n elements of s's spare
+// capacity were taken by TakeBytes (through another view of the same
+// allocation), so that appending in place cannot write into them
+func (s Slice[T]) spareLive(n int) bool {
+	taken := s.arena.taken.Load()
+	if taken == nil {
+		return true
+	}
+	spare := s.slice[len(s.slice) : len(s.slice)+n]
+	return !taken.overlaps(unsafe.Pointer(unsafe.SliceData(spare)), uintptr(n)*unsafe.Sizeof(*new(T)))
+}
+
+// GrowSlice is the arena's realloc: it allocates a newSize-element slice in
+// a (usually s's arena), copies s's elements into its start, and returns it.
+// The added elements are zeroed. Arena memory cannot be resized in place, so
+// the old Slice stays valid and allocated until its arena is freed; code that
+// grows repeatedly should grow geometrically, as AppendSlice does, to bound
+// the waste.
+//
+// Panics if newSize is smaller than s's length, or if either arena has been
+// freed (or s's arena reset since s was allocated).
+//
+// Example:
+//
+//	table := safearena.AllocSlice[Entry](a, 16)
+//	// ... table fills up ...
+//	table = safearena.GrowSlice(a, table, 2*table.Len())
+func GrowSlice[T any](a *Arena, s Slice[T], newSize int) Slice[T] {
+	if a.debug != nil {
+		defer recordTiming(opAllocSlice, time.Now())
+	}
+	if s.arena.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		site := s.arena.allocSite(unsafe.Pointer(unsafe.SliceData(s.slice)))
+		panic(s.arena.staleError(s.gen, stack, site))
+	}
+	if newSize < len(s.slice) {
+		panic(fmt.Sprintf("safearena: GrowSlice to %d elements, fewer than the current %d", newSize, len(s.slice)))
+	}
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	grown := makeSlice[T](a, newSize, newSize)
+	copy(grown, s.slice)
+	return Slice[T]{slice: grown, arena: a, gen: a.gen.Load()}
+}
+
+// makeSlice allocates a []T with the given length and capacity in the arena,
+// charging it against the budget and recording it in debug mode
+func makeSlice[T any](a *Arena, length, capacity int) []T {
+	size := uintptr(capacity) * unsafe.Sizeof(*new(T))
+	if !a.charge(int64(size)) {
+		stack := captureStack(3)
+		panic(a.chargeError(stack))
+	}
+
+	slice := backingMakeSlice[T](a.inner, length, capacity)
+	if a.debug != nil && capacity > 0 {
+		a.recordSliceAlloc(unsafe.Pointer(unsafe.SliceData(slice)), captureStack(3),
+			reflect.TypeFor[[]T](), size)
+	}
+	return slice
+}
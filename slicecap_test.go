@@ -0,0 +1,150 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestAllocSliceCap(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := AllocSliceCap[int](a, 2, 8)
+	if got := s.Get(); len(got) != 2 || cap(got) != 8 {
+		t.Errorf("expected len 2 cap 8, got len %d cap %d", len(got), cap(got))
+	}
+	if got := a.Stats().Bytes; got != 64 {
+		t.Errorf("expected full capacity charged (64 bytes), got %d", got)
+	}
+}
+
+func TestAppendSlice(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := AllocSliceCap[int](a, 0, 2)
+	data := unsafe.SliceData(s.Get()[:1])
+
+	s = AppendSlice(s, 1, 2)
+	if unsafe.SliceData(s.Get()) != data {
+		t.Error("expected append within capacity not to reallocate")
+	}
+
+	s = AppendSlice(s, 3)
+	got := s.Get()
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if cap(got) != 4 {
+		t.Errorf("expected capacity to double to 4, got %d", cap(got))
+	}
+}
+
+func TestAllocSliceCapInvalid(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "invalid slice length") {
+			t.Errorf("expected invalid slice length panic, got %q", msg)
+		}
+	}()
+	AllocSliceCap[int](a, 4, 2)
+}
+
+func TestAppendSliceAfterFree(t *testing.T) {
+	a := New()
+	s := AllocSliceCap[int](a, 0, 4)
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	AppendSlice(s, 1)
+}
+
+func TestAppendSliceBudget(t *testing.T) {
+	a := NewWithLimit(32)
+	defer a.Free()
+
+	s := AllocSliceCap[int64](a, 0, 4)
+	s = AppendSlice(s, 1, 2, 3, 4)
+
+	expectBudgetPanic(t, func() { AppendSlice(s, 5) })
+}
+
+func TestGrowSlice(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	small := AllocSlice[int](a, 10)
+	for i := range small.Get() {
+		small.Get()[i] = i * i
+	}
+	big := GrowSlice(a, small, 1000)
+
+	if big.Len() != 1000 {
+		t.Fatalf("expected 1000 elements, got %d", big.Len())
+	}
+	for i, v := range big.Get() {
+		want := 0
+		if i < 10 {
+			want = i * i
+		}
+		if v != want {
+			t.Fatalf("expected element %d to be %d, got %d", i, want, v)
+		}
+	}
+	if !small.Valid() || small.Get()[9] != 81 {
+		t.Error("expected the old slice to stay valid and unchanged")
+	}
+	big.Get()[0] = -1
+	if small.Get()[0] != 0 {
+		t.Error("expected the grown slice to have its own storage")
+	}
+	if stats := a.Stats(); stats.Bytes != 1010*int64(unsafe.Sizeof(0)) {
+		t.Errorf("expected both slices to be charged, got %+v", stats)
+	}
+}
+
+func TestGrowSliceInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		grow func()
+		want string
+	}{
+		"shrink": {func() {
+			a := New()
+			defer a.Free()
+			GrowSlice(a, AllocSlice[int](a, 10), 5)
+		}, "GrowSlice to 5 elements, fewer than the current 10"},
+		"freed source": {func() {
+			a, b := New(), New()
+			defer b.Free()
+			s := AllocSlice[int](a, 10)
+			a.Free()
+			GrowSlice(b, s, 20)
+		}, "use after free"},
+		"freed destination": {func() {
+			a, b := New(), New()
+			defer a.Free()
+			b.Free()
+			GrowSlice(b, AllocSlice[int](a, 10), 20)
+		}, "allocation after free"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tc.want) {
+					t.Errorf("expected panic containing %q, got %q", tc.want, msg)
+				}
+			}()
+			tc.grow()
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package safearena
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AllocGroup summarizes the allocations of one type made from one call site
+type AllocGroup struct {
+	Type  string // Allocated type, e.g. "main.Node" or "[]uint8"
+	Site  string // Call site as "file:line (function)", or "unknown"
+	Count int    // Number of allocations
+	Bytes int    // Total bytes allocated
+}
+
+// Snapshot is a point-in-time summary of a debug-mode arena's live
+// allocations (those made since the last Reset), grouped by type and call site
+type Snapshot struct {
+	Groups []AllocGroup // Sorted by Type, then Site
+}
+
+// SnapshotDiff reports how allocations grew between two snapshots
+type SnapshotDiff struct {
+	Grown []AllocGroup // Growth per type and call site, largest Bytes first
+}
+
+// Snapshot captures the arena's live allocations for later comparison with
+// DiffSnapshots. Only arenas created while Debug is set record allocations;
+// other arenas, and freed arenas, return an empty Snapshot.
+//
+// Example:
+//
+//	before := a.Snapshot()
+//	handle(a, req)
+//	after := a.Snapshot()
+//	fmt.Print(safearena.DiffSnapshots(before, after))
+func (a *Arena) Snapshot() Snapshot {
+	if a.debug == nil || a.freed.Load() {
+		return Snapshot{}
+	}
+
+	groups := make(map[[2]string]*AllocGroup)
+
+	a.debug.mu.Lock()
+	for _, rec := range a.debug.allocs {
+		if !a.live(rec.gen) {
+			continue // Released by Reset or Release
+		}
+		key := [2]string{rec.typ.String(), siteString(rec.site)}
+		g, ok := groups[key]
+		if !ok {
+			g = &AllocGroup{Type: key[0], Site: key[1]}
+			groups[key] = g
+		}
+		g.Count++
+		g.Bytes += int(rec.size)
+	}
+	a.debug.mu.Unlock()
+
+	snap := Snapshot{Groups: make([]AllocGroup, 0, len(groups))}
+	for _, g := range groups {
+		snap.Groups = append(snap.Groups, *g)
+	}
+	slices.SortFunc(snap.Groups, func(x, y AllocGroup) int {
+		return cmp.Or(cmp.Compare(x.Type, y.Type), cmp.Compare(x.Site, y.Site))
+	})
+	return snap
+}
+
+// DiffSnapshots reports which types and call sites gained allocations between
+// before and after. Groups that shrank or stayed the same are omitted.
+func DiffSnapshots(before, after Snapshot) SnapshotDiff {
+	prev := make(map[[2]string]AllocGroup, len(before.Groups))
+	for _, g := range before.Groups {
+		prev[[2]string{g.Type, g.Site}] = g
+	}
+
+	var diff SnapshotDiff
+	for _, g := range after.Groups {
+		old := prev[[2]string{g.Type, g.Site}]
+		if g.Count > old.Count || g.Bytes > old.Bytes {
+			diff.Grown = append(diff.Grown, AllocGroup{
+				Type:  g.Type,
+				Site:  g.Site,
+				Count: g.Count - old.Count,
+				Bytes: g.Bytes - old.Bytes,
+			})
+		}
+	}
+	slices.SortStableFunc(diff.Grown, func(x, y AllocGroup) int {
+		return cmp.Compare(y.Bytes, x.Bytes)
+	})
+	return diff
+}
+
+// String formats the diff as one line per grown group
+func (d SnapshotDiff) String() string {
+	var b strings.Builder
+	for _, g := range d.Grown {
+		fmt.Fprintf(&b, "+%d bytes (%d allocs) %s at %s\n", g.Bytes, g.Count, g.Type, g.Site)
+	}
+	return b.String()
+}
+
+// siteString formats a call site for display
+func siteString(site *stackInfo) string {
+	if site == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d (%s)", site.File, site.Line, site.Function)
+}
@@ -0,0 +1,92 @@
+package safearena
+
+import (
+	"strings"
+	"testing"
+)
+
+type snapshotItem struct {
+	ID   int
+	Name [16]byte
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	_ = Alloc(a, 1)
+	_ = AllocSlice[byte](a, 32)
+	before := a.Snapshot()
+
+	for i := 0; i < 5; i++ {
+		_ = Alloc(a, snapshotItem{ID: i}) // growth under test
+	}
+	after := a.Snapshot()
+
+	diff := DiffSnapshots(before, after)
+	if len(diff.Grown) != 1 {
+		t.Fatalf("expected growth in exactly one group, got %+v", diff.Grown)
+	}
+
+	g := diff.Grown[0]
+	if g.Type != "safearena.snapshotItem" {
+		t.Errorf("expected growth attributed to snapshotItem, got %s", g.Type)
+	}
+	if !strings.HasPrefix(g.Site, "snapshot_test.go:") || !strings.Contains(g.Site, "TestSnapshotDiff") {
+		t.Errorf("expected growth attributed to the test's call site, got %s", g.Site)
+	}
+	if g.Count != 5 {
+		t.Errorf("expected 5 allocations, got %d", g.Count)
+	}
+	if want := 5 * 24; g.Bytes != want {
+		t.Errorf("expected %d bytes, got %d", want, g.Bytes)
+	}
+	if !strings.Contains(diff.String(), "safearena.snapshotItem") {
+		t.Errorf("expected type in diff output, got %q", diff.String())
+	}
+}
+
+func TestSnapshotDiffOrdering(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	before := a.Snapshot()
+	_ = Alloc(a, 1)
+	_ = AllocSlice[byte](a, 1024)
+	diff := DiffSnapshots(before, a.Snapshot())
+
+	if len(diff.Grown) != 2 {
+		t.Fatalf("expected 2 grown groups, got %+v", diff.Grown)
+	}
+	if diff.Grown[0].Type != "[]uint8" || diff.Grown[0].Bytes != 1024 {
+		t.Errorf("expected largest growth first, got %+v", diff.Grown[0])
+	}
+}
+
+func TestSnapshotAfterReset(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+
+	_ = AllocSlice[int](a, 100)
+	a.Reset()
+
+	if snap := a.Snapshot(); len(snap.Groups) != 0 {
+		t.Errorf("expected no live allocations after reset, got %+v", snap.Groups)
+	}
+}
+
+func TestSnapshotWithoutDebug(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	_ = Alloc(a, 1)
+	if snap := a.Snapshot(); len(snap.Groups) != 0 {
+		t.Errorf("expected empty snapshot without Debug, got %+v", snap.Groups)
+	}
+}
@@ -0,0 +1,247 @@
+package safearena
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ErrBudgetExceeded is returned by TryAlloc when an allocation would push an
+// arena created with NewWithLimit past its byte budget
+var ErrBudgetExceeded = errors.New("arena budget exceeded")
+
+// Stats reports an arena's allocation activity since it was created or last
+// reset. HighWater is the exception: it spans the arena's whole lifetime.
+type Stats struct {
+	Allocations int64 // Number of allocations
+	Bytes       int64 // Total bytes allocated
+	HighWater   int64 // Peak Bytes, across Resets
+}
+
+// statsCounters accumulates Stats for an arena
+type statsCounters struct {
+	allocs    atomic.Int64
+	bytes     atomic.Int64
+	highWater atomic.Int64
+}
+
+// add records one allocation of n bytes and raises the high-water mark
+func (c *statsCounters) add(n int64) {
+	c.allocs.Add(1)
+	total := c.bytes.Add(n)
+	for peak := c.highWater.Load(); total > peak; peak = c.highWater.Load() {
+		if c.highWater.CompareAndSwap(peak, total) {
+			break
+		}
+	}
+}
+
+// snapshot returns the current counter values
+func (c *statsCounters) snapshot() Stats {
+	return Stats{
+		Allocations: c.allocs.Load(),
+		Bytes:       c.bytes.Load(),
+		HighWater:   c.highWater.Load(),
+	}
+}
+
+// NewWithLimit creates an arena that allows at most maxBytes of cumulative
+// allocation. An allocation that would exceed the budget panics with
+// "arena budget exceeded" (or, with TryAlloc, returns ErrBudgetExceeded)
+// instead of allocating. Reset restores the full budget.
+//
+// Use it to bound the memory a single request can consume in a multi-tenant
+// service.
+//
+// Example:
+//
+//	a := safearena.NewWithLimit(16 << 20) // 16 MiB per request
+//	defer a.Free()
+func NewWithLimit(maxBytes int64) *Arena {
+	a := New()
+	a.limit = maxBytes
+	return a
+}
+
+// Stats returns the number of allocations and bytes allocated since the arena
+// was created or last reset, and the high-water mark (see HighWaterMark).
+func (a *Arena) Stats() Stats {
+	return a.stats.snapshot()
+}
+
+// Sub returns the activity between two Stats of the same arena, s taken after
+// before: the allocations and bytes in between, and how much HighWater rose.
+// It turns allocation behavior into exact test assertions, which catch
+// performance regressions that benchmarks only hint at:
+//
+//	before := a.Stats()
+//	buildIndex(a, docs)
+//	if d := a.Stats().Sub(before); d.Allocations != 3 {
+//	    t.Errorf("buildIndex made %d arena allocations, want 3", d.Allocations)
+//	}
+//
+// A Reset between the two clears the counters, which makes the result
+// meaningless; take both within one generation.
+func (s Stats) Sub(before Stats) Stats {
+	return Stats{
+		Allocations: s.Allocations - before.Allocations,
+		Bytes:       s.Bytes - before.Bytes,
+		HighWater:   s.HighWater - before.HighWater,
+	}
+}
+
+// HighWaterMark returns the most bytes the arena has held at once over its
+// lifetime. Unlike Stats().Bytes it is not cleared by Reset, so for an arena
+// reused across requests it is the size of the largest request, which is a
+// good starting point for Reserve.
+//
+// Example:
+//
+//	a := safearena.New()
+//	for _, req := range requests {
+//	    handle(a, req)
+//	    a.Reset()
+//	}
+//	log.Printf("peak arena size: %d bytes", a.HighWaterMark())
+func (a *Arena) HighWaterMark() int64 {
+	return a.stats.highWater.Load()
+}
+
+// SizeHistogram returns the distribution of allocation sizes in a debug-mode
+// arena (see Debug) since it was created or last reset, like Stats: element
+// i counts the allocations whose size rounds up to 2^i bytes (1 byte, 2, 3-4,
+// 5-8, 9-16, ...; zero-sized allocations count in element 0). The slice ends
+// at the largest non-empty bucket. Arenas created without Debug return nil.
+//
+// Many entries at the low end mean per-allocation overhead dominates, and a
+// pool (see ObjectPool) or batching (see AllocN) may help; a few large
+// entries suggest sizing the arena up front with Reserve.
+//
+// Example:
+//
+//	for i, n := range a.SizeHistogram() {
+//	    fmt.Printf("<= %6d B: %d\n", 1<<i, n)
+//	}
+func (a *Arena) SizeHistogram() []int {
+	if a.debug == nil {
+		return nil
+	}
+	a.debug.mu.Lock()
+	defer a.debug.mu.Unlock()
+
+	last := -1
+	for i, n := range a.debug.sizes {
+		if n > 0 {
+			last = i
+		}
+	}
+	return slices.Clone(a.debug.sizes[:last+1])
+}
+
+// sizeBucket returns the SizeHistogram bucket for an allocation of size bytes
+func sizeBucket(size uintptr) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len64(uint64(size - 1))
+}
+
+// NewOptWithStats creates an optimized arena that counts allocations and
+// bytes like the standard Arena, for monitoring in production. Each
+// allocation pays a few atomic adds; NewOpt arenas skip the counters
+// entirely. Get is unaffected either way.
+//
+// Example:
+//
+//	a := safearena.NewOptWithStats()
+//	defer a.Free()
+//	handle(a, req)
+//	metrics.Observe(a.Stats().Bytes)
+func NewOptWithStats() *ArenaOpt {
+	a := NewOpt()
+	a.stats = new(statsCounters)
+	return a
+}
+
+// Stats returns the number of allocations and bytes allocated by an arena
+// created with NewOptWithStats. For other optimized arenas it returns zero
+// Stats. As for Arena, HighWater is the peak across Resets.
+func (a *ArenaOpt) Stats() Stats {
+	if a.stats == nil {
+		return Stats{}
+	}
+	return a.stats.snapshot()
+}
+
+// TryAlloc is like Alloc but returns ErrBudgetExceeded instead of panicking
+// when the allocation would exceed the arena's budget (see NewWithLimit).
+//
+// Panics if the arena has already been freed.
+func TryAlloc[T any](a *Arena, value T) (Ptr[T], error) {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	if !a.withinBudget(int64(unsafe.Sizeof(value))) {
+		return Ptr[T]{}, ErrBudgetExceeded
+	}
+	return Alloc(a, value), nil
+}
+
+// withinBudget reports whether n more bytes fit in the arena's budget
+func (a *Arena) withinBudget(n int64) bool {
+	return a.limit <= 0 || a.stats.bytes.Load()+n <= a.limit
+}
+
+// charge records an allocation of n bytes.
+// It reports false, recording nothing, if the arena is frozen or n bytes
+// exceed the budget; chargeError describes the failure.
+func (a *Arena) charge(n int64) bool {
+	if a.frozen.Load() || !a.withinBudget(n) {
+		return false
+	}
+	a.stats.add(n)
+	if OnAlloc != nil {
+		OnAlloc(a.id, int(n))
+	}
+	return true
+}
+
+// chargeError creates the panic message for an allocation rejected by charge
+func (a *Arena) chargeError(stack *stackInfo) *ArenaError {
+	if a.frozen.Load() {
+		return errorWithHint(a, "allocation in frozen arena", stack, AllocInFrozen)
+	}
+	return errorWithHint(a, "arena budget exceeded", stack, BudgetExceeded)
+}
+
+// makeBytes allocates a zeroed byte slice in the arena, from the Reserve
+// reservation when it has room, charging capacity bytes against the budget.
+// The caller must have checked that the arena is live.
+func (a *Arena) makeBytes(length, capacity int) []byte {
+	if !a.charge(int64(capacity)) {
+		stack := captureStack(3)
+		panic(a.chargeError(stack))
+	}
+	if capacity <= len(a.reserved) {
+		buf := a.reserved[:length:capacity]
+		a.reserved = a.reserved[capacity:]
+		return buf
+	}
+	return backingMakeSlice[byte](a.inner, length, capacity)
+}
+
+// growBuf allocates a zeroed buffer of size bytes for StringBuilder
+func (a *Arena) growBuf(size int) []byte {
+	return a.makeBytes(size, size)
+}
+
+// growBuf allocates a zeroed buffer of size bytes for StringBuilderOpt
+func (a *ArenaOpt) growBuf(size int) []byte {
+	if a.stats != nil {
+		a.stats.add(int64(size))
+	}
+	return backingMakeSlice[byte](a.inner, size, size)
+}
@@ -0,0 +1,209 @@
+package safearena
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	_ = Alloc(a, int64(1))
+	_ = AllocSlice[int32](a, 10)
+	_ = a.AllocBytes(100)
+
+	stats := a.Stats()
+	if stats.Allocations != 3 {
+		t.Errorf("expected 3 allocations, got %d", stats.Allocations)
+	}
+	if want := int64(8 + 40 + 100); stats.Bytes != want {
+		t.Errorf("expected %d bytes, got %d", want, stats.Bytes)
+	}
+
+	a.Reset()
+	if stats := a.Stats(); stats != (Stats{HighWater: 148}) {
+		t.Errorf("expected zero stats except HighWater after reset, got %+v", stats)
+	}
+}
+
+func TestHighWaterMark(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	_ = a.AllocBytes(100)
+	a.Reset()
+	_ = a.AllocBytes(40)
+
+	if got := a.HighWaterMark(); got != 100 {
+		t.Errorf("expected high-water mark 100, got %d", got)
+	}
+
+	_ = a.AllocBytes(80)
+	if got := a.HighWaterMark(); got != 120 {
+		t.Errorf("expected high-water mark 120, got %d", got)
+	}
+}
+
+// expectBudgetPanic fails the test unless fn panics with a budget error
+func expectBudgetPanic(t *testing.T, fn func()) {
+	t.Helper()
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "arena budget exceeded") {
+			t.Errorf("expected budget panic, got %q", msg)
+		}
+	}()
+	fn()
+}
+
+func TestNewWithLimit(t *testing.T) {
+	a := NewWithLimit(64)
+	defer a.Free()
+
+	// Exactly up to the limit succeeds
+	_ = AllocSlice[byte](a, 56)
+	_ = Alloc(a, int64(1))
+	if got := a.Stats().Bytes; got != 64 {
+		t.Fatalf("expected 64 bytes used, got %d", got)
+	}
+
+	// One byte over fails
+	expectBudgetPanic(t, func() { _ = Alloc(a, byte(1)) })
+
+	// The failed allocation is not counted
+	if got := a.Stats().Bytes; got != 64 {
+		t.Errorf("expected rejected allocation not to be charged, got %d bytes", got)
+	}
+}
+
+func TestNewWithLimitAllPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		alloc func(a *Arena)
+	}{
+		{"alloc", func(a *Arena) { _ = Alloc(a, [17]byte{}) }},
+		{"alloc slice", func(a *Arena) { _ = AllocSlice[byte](a, 17) }},
+		{"alloc n", func(a *Arena) { _ = AllocN[byte](a, 17) }},
+		{"alloc bytes", func(a *Arena) { _ = a.AllocBytes(17) }},
+		{"writer", func(a *Arena) { a.NewWriter(8).Write(make([]byte, 17)) }},
+		{"buffer", func(a *Arena) { a.NewBuffer(17) }},
+		{"string builder", func(a *Arena) {
+			sb := NewStringBuilder(a, 8)
+			sb.Get().Append(strings.Repeat("x", 9))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewWithLimit(16)
+			defer a.Free()
+			expectBudgetPanic(t, func() { tt.alloc(a) })
+		})
+	}
+}
+
+func TestTryAlloc(t *testing.T) {
+	a := NewWithLimit(8)
+	defer a.Free()
+
+	p, err := TryAlloc(a, int64(42))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Deref() != 42 {
+		t.Errorf("expected 42, got %d", p.Deref())
+	}
+
+	if _, err := TryAlloc(a, byte(1)); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+}
+
+func TestNewWithLimitReset(t *testing.T) {
+	a := NewWithLimit(8)
+	defer a.Free()
+
+	_ = Alloc(a, int64(1))
+	a.Reset()
+	_ = Alloc(a, int64(2)) // Budget restored by Reset
+}
+
+func TestOptStats(t *testing.T) {
+	a := NewOptWithStats()
+	defer a.Free()
+
+	_ = AllocOpt(a, int64(1))
+	_ = AllocSliceOpt[int32](a, 10)
+	_ = a.AllocBytes(16)
+
+	want := Stats{Allocations: 3, Bytes: 64, HighWater: 64}
+	if stats := a.Stats(); stats != want {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	plain := NewOpt()
+	defer plain.Free()
+	_ = AllocOpt(plain, int64(1))
+	if stats := plain.Stats(); stats != (Stats{}) {
+		t.Errorf("expected zero stats without NewOptWithStats, got %+v", stats)
+	}
+}
+
+func TestStatsSub(t *testing.T) {
+	a := New()
+	defer a.Free()
+	_ = Alloc(a, int64(1))
+
+	before := a.Stats()
+	_ = Alloc(a, [4]int64{})
+	_ = AllocSlice[byte](a, 100)
+	d := a.Stats().Sub(before)
+
+	if d.Allocations != 2 || d.Bytes != 132 || d.HighWater != 132 {
+		t.Errorf("expected 2 allocations and 132 bytes, got %+v", d)
+	}
+	if z := a.Stats().Sub(a.Stats()); z != (Stats{}) {
+		t.Errorf("expected zero diff, got %+v", z)
+	}
+}
+
+func TestSizeHistogram(t *testing.T) {
+	enableDebug(t)
+
+	a := New()
+	defer a.Free()
+	Alloc(a, int8(1))         // 1 byte: bucket 0
+	Alloc(a, struct{}{})      // 0 bytes: bucket 0
+	Alloc(a, int16(1))        // 2 bytes: bucket 1
+	Alloc(a, int32(1))        // 4 bytes: bucket 2
+	Alloc(a, float32(1))      // 4 bytes: bucket 2
+	AllocSlice[byte](a, 5)    // 5 bytes: bucket 3
+	AllocSlice[byte](a, 100)  // 100 bytes: bucket 7
+	AllocSlice[int64](a, 128) // 1024 bytes: bucket 10
+	want := []int{2, 1, 2, 1, 0, 0, 0, 1, 0, 0, 1}
+
+	if got := a.SizeHistogram(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected histogram %v, got %v", want, got)
+	}
+
+	a.Reset()
+	if got := a.SizeHistogram(); len(got) != 0 {
+		t.Errorf("expected Reset to clear the histogram, got %v", got)
+	}
+	Alloc(a, int64(1))
+	if got := a.SizeHistogram(); fmt.Sprint(got) != "[0 0 0 1]" {
+		t.Errorf("expected one 8-byte allocation after Reset, got %v", got)
+	}
+}
+
+func TestSizeHistogramWithoutDebug(t *testing.T) {
+	a := New()
+	defer a.Free()
+	Alloc(a, 1)
+	if got := a.SizeHistogram(); got != nil {
+		t.Errorf("expected nil without Debug, got %v", got)
+	}
+}
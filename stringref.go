@@ -0,0 +1,77 @@
+package safearena
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// StringRef is a string whose bytes live in the arena, for parsers and
+// interning-heavy workloads that produce many short-lived strings. Like
+// Ptr[T], it carries its arena and generation, and Get panics once the arena
+// is freed or reset.
+//
+// Get builds a Go string header over arena memory with unsafe.String. That
+// string is only as safe as the arena: the runtime treats strings as
+// immutable and never frees their bytes, but Free and Reset do. The string
+// returned by Get must therefore not be stored anywhere that outlives the
+// arena (maps, globals, struct fields on the heap, other goroutines); read it,
+// compare it, or copy it with strings.Clone, and keep the StringRef instead.
+type StringRef struct {
+	data  *byte
+	len   int
+	arena *Arena
+	gen   uint64 // Arena generation at allocation
+}
+
+// AllocString copies s into the arena and returns a StringRef to the copy.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	name := safearena.AllocString(a, string(token))
+//	isCT := name.Get() == "Content-Type" // OK while the arena is alive
+//	keep := strings.Clone(name.Get())    // Copy to the heap to keep it
+func AllocString(a *Arena, s string) StringRef {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	buf := a.makeBytes(len(s), len(s))
+	copy(buf, s)
+	if a.debug != nil && len(s) > 0 {
+		a.recordSliceAlloc(unsafe.Pointer(unsafe.SliceData(buf)), captureStack(2), reflect.TypeFor[[]byte](), uintptr(len(s)))
+	}
+	return StringRef{
+		data:  unsafe.SliceData(buf),
+		len:   len(buf),
+		arena: a,
+		gen:   a.gen.Load(),
+	}
+}
+
+// Get returns the string, backed by arena memory.
+// The result is valid only while the arena is alive; see StringRef.
+//
+// Panics if the arena has been freed or reset.
+func (r StringRef) Get() string {
+	if r.arena.freed.Load() || !r.arena.live(r.gen) {
+		stack := captureStack(2)
+		panic(r.arena.staleError(r.gen, stack, r.arena.allocSite(unsafe.Pointer(r.data))))
+	}
+	return unsafe.String(r.data, r.len)
+}
+
+// Len returns the length of the string in bytes, without a lifetime check
+func (r StringRef) Len() int {
+	return r.len
+}
+
+// Valid reports whether Get would succeed. The zero StringRef is not valid.
+//
+// Like Ptr.Valid, the result is only a snapshot when other goroutines may
+// free or reset the arena concurrently.
+func (r StringRef) Valid() bool {
+	return r.arena != nil && !r.arena.freed.Load() && r.arena.live(r.gen)
+}
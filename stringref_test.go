@@ -0,0 +1,68 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAllocString(t *testing.T) {
+	a := New()
+	src := []byte("Content-Type")
+	ref := AllocString(a, string(src))
+	src[0] = 'X' // The arena copy is independent of the source
+
+	if ref.Get() != "Content-Type" {
+		t.Errorf("expected %q, got %q", "Content-Type", ref.Get())
+	}
+	if ref.Len() != len("Content-Type") {
+		t.Errorf("expected length %d, got %d", len("Content-Type"), ref.Len())
+	}
+	if !ref.Valid() {
+		t.Error("expected ref to be valid")
+	}
+	if empty := AllocString(a, ""); empty.Get() != "" {
+		t.Errorf("expected empty string, got %q", empty.Get())
+	}
+
+	kept := strings.Clone(ref.Get())
+	a.Free()
+	if kept != "Content-Type" {
+		t.Errorf("expected cloned string to survive Free, got %q", kept)
+	}
+	if ref.Valid() {
+		t.Error("expected ref to be invalid after Free")
+	}
+	if (StringRef{}).Valid() {
+		t.Error("expected zero StringRef to be invalid")
+	}
+}
+
+func TestAllocStringAfterFree(t *testing.T) {
+	a := New()
+	ref := AllocString(a, "data")
+	a.Free()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after free") {
+			t.Errorf("expected use after free panic, got %q", msg)
+		}
+	}()
+	_ = ref.Get()
+}
+
+func TestAllocStringAfterReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+	ref := AllocString(a, "data")
+	a.Reset()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after reset") {
+			t.Errorf("expected use after reset panic, got %q", msg)
+		}
+	}()
+	_ = ref.Get()
+}
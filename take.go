@@ -0,0 +1,120 @@
+package safearena
+
+import (
+	"slices"
+	"sort"
+	"unsafe"
+)
+
+// span is a range of memory moved out by TakeBytes. base keeps the memory
+// reachable, so that without arenas it cannot be reused for a later
+// allocation while it is still recorded as taken.
+type span struct {
+	base unsafe.Pointer
+	size uintptr
+}
+
+// takenSet holds the memory moved out by TakeBytes, as disjoint spans sorted
+// by address. It is copy-on-write: readers may hold an old set while
+// TakeBytes adds.
+type takenSet []span
+
+// overlaps reports whether any of the size bytes at data have been taken
+func (t takenSet) overlaps(data unsafe.Pointer, size uintptr) bool {
+	if size == 0 {
+		return false
+	}
+	start, end := uintptr(data), uintptr(data)+size
+	i := sort.Search(len(t), func(i int) bool { return uintptr(t[i].base) >= end })
+	return i > 0 && uintptr(t[i-1].base)+t[i-1].size > start
+}
+
+// with returns a copy of t that also holds sp
+func (t takenSet) with(sp span) takenSet {
+	i := sort.Search(len(t), func(i int) bool { return uintptr(t[i].base) >= uintptr(sp.base) })
+	return slices.Insert(slices.Clip(t), i, sp)
+}
+
+// TakeBytes moves the contents of s to the heap: it returns a heap copy and
+// invalidates s, so any further Get, At, SetAt, or other access through s
+// (or copies of it) panics with "use after TakeBytes". This is clearer than
+// CloneSlice followed by ignoring the original, and catches code that
+// accidentally keeps using both the arena slice and its heap copy.
+//
+// What is taken is the memory of s's elements, not the whole allocation: if
+// s is a view (see Slice.Slice), every Slice that shares one of its elements
+// becomes invalid, including the slice it was cut from, while views of the
+// rest of the allocation stay valid. An empty s has nothing to move and stays
+// valid.
+//
+// Go arenas cannot free part of their memory, so the arena storage is not
+// reclaimed until the arena is freed or reset. In debug mode it is poisoned
+// so that reads through UnsafeGet or Data show garbage.
+//
+// Panics if the arena has been freed or reset, if s has already been taken,
+// or if s belongs to a different arena.
+//
+// Example:
+//
+//	body := safearena.AllocSlice[byte](a, size)
+//	render(body.Get())
+//	return a.TakeBytes(body) // Outlives the arena; body is now invalid
+func (a *Arena) TakeBytes(s Slice[byte]) []byte {
+	if s.arena != a {
+		panic("safearena: TakeBytes of a slice from a different arena")
+	}
+	data := unsafe.Pointer(unsafe.SliceData(s.slice))
+	if a.freed.Load() || !s.live() {
+		stack := captureStack(2)
+		panic(a.staleError(s.gen, stack, a.allocSite(data)))
+	}
+
+	out := make([]byte, len(s.slice))
+	copy(out, s.slice)
+	if len(s.slice) == 0 {
+		return out // Nothing in the arena to invalidate
+	}
+
+	for {
+		old := a.taken.Load()
+		var next takenSet
+		if old != nil {
+			next = *old
+		}
+		next = next.with(span{base: data, size: uintptr(len(s.slice))})
+		if a.taken.CompareAndSwap(old, &next) {
+			break
+		}
+	}
+	a.gen.Add(1) // Move existing values off the fast path so they see the take
+
+	if a.debug != nil {
+		for i := range s.slice {
+			s.slice[i] = poisonPattern[i%len(poisonPattern)]
+		}
+	}
+	return out
+}
+
+// live reports whether s can be accessed: its generation is live and none of
+// its elements were taken by TakeBytes. The caller checks for Free.
+func (s Slice[T]) live() bool {
+	return s.arena.liveSlice(s.gen, unsafe.Pointer(unsafe.SliceData(s.slice)), uintptr(len(s.slice))*unsafe.Sizeof(*new(T)))
+}
+
+// liveSlice is live for the size bytes of Slice data at data: it also fails
+// if any of them were taken by TakeBytes. TakeBytes advances the generation,
+// so the taken set is only consulted on the slow path.
+func (a *Arena) liveSlice(gen uint64, data unsafe.Pointer, size uintptr) bool {
+	return gen == a.gen.Load() || a.liveSliceSlow(gen, data, size)
+}
+
+// liveSliceSlow reports whether generation gen is live and none of the size
+// bytes at data have been taken
+func (a *Arena) liveSliceSlow(gen uint64, data unsafe.Pointer, size uintptr) bool {
+	if !a.liveSlow(gen) {
+		return false
+	}
+	taken := a.taken.Load()
+	return taken == nil || !taken.overlaps(data, size)
+}
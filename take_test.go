@@ -0,0 +1,165 @@
+package safearena
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTakeBytes(t *testing.T) {
+	a := New()
+	s := AllocSlice[byte](a, 5)
+	copy(s.Get(), "hello")
+	other := AllocSlice[byte](a, 3)
+	p := Alloc(a, 42)
+
+	out := a.TakeBytes(s)
+	if string(out) != "hello" {
+		t.Errorf("expected %q, got %q", "hello", out)
+	}
+	if s.Valid() {
+		t.Error("expected taken slice to be invalid")
+	}
+	if !other.Valid() || *p.Get() != 42 {
+		t.Error("expected other values to stay valid")
+	}
+	later := AllocSlice[byte](a, 3)
+	if !later.Valid() {
+		t.Error("expected slice allocated after TakeBytes to be valid")
+	}
+
+	a.Free()
+	if string(out) != "hello" {
+		t.Errorf("expected heap copy to survive Free, got %q", out)
+	}
+}
+
+func TestTakeBytesUseAfterTake(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(s Slice[byte])
+	}{
+		{"get", func(s Slice[byte]) { s.Get() }},
+		{"at", func(s Slice[byte]) { s.At(0) }},
+		{"set at", func(s Slice[byte]) { s.SetAt(0, 'x') }},
+		{"len", func(s Slice[byte]) { s.Len() }},
+		{"append", func(s Slice[byte]) { AppendSlice(s, 'x') }},
+		{"take again", func(s Slice[byte]) { s.arena.TakeBytes(s) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			defer a.Free()
+			s := AllocSlice[byte](a, 4)
+			a.TakeBytes(s)
+
+			defer func() {
+				var ae *ArenaError
+				r := recover()
+				if err, ok := r.(error); !ok || !errors.As(err, &ae) || ae.Kind != UseAfterTake {
+					t.Errorf("expected UseAfterTake panic, got %v", r)
+				}
+				if !strings.Contains(fmt.Sprint(r), "use after TakeBytes") {
+					t.Errorf("expected use after TakeBytes message, got %q", fmt.Sprint(r))
+				}
+			}()
+			tt.use(s)
+		})
+	}
+}
+
+func TestTakeBytesView(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := AllocSlice[byte](a, 32)
+	head, tail := buf.Slice(0, 16), buf.Slice(16, 32)
+	straddling, empty := buf.Slice(8, 24), buf.Slice(4, 4)
+	a.TakeBytes(head)
+
+	for name, tt := range map[string]struct {
+		s    Slice[byte]
+		want bool
+	}{
+		"taken view":      {head, false},
+		"whole slice":     {buf, false},
+		"straddling view": {straddling, false},
+		"disjoint view":   {tail, true},
+		"empty view":      {empty, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tt.s.Valid() != tt.want {
+				t.Errorf("expected Valid() = %v", tt.want)
+			}
+		})
+	}
+
+	tail.Get()[0] = 'x' // Still usable
+}
+
+func TestTakeBytesViewOfTaken(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := AllocSlice[byte](a, 8)
+	view := buf.Slice(1, 8)
+	a.TakeBytes(buf)
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after TakeBytes") {
+			t.Errorf("expected use after TakeBytes panic, got %q", msg)
+		}
+	}()
+	view.Get()
+}
+
+func TestAppendSliceAroundTakenSpare(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	buf := AllocSlice[byte](a, 16)
+	head, tail := buf.Slice(0, 8), buf.Slice(8, 16)
+	copy(tail.Get(), "taken!!!")
+	out := a.TakeBytes(tail)
+
+	grown := AppendSlice(head, 'x')
+	if grown.DataPtr() == head.DataPtr() {
+		t.Error("expected AppendSlice to reallocate instead of writing into taken memory")
+	}
+	if string(out) != "taken!!!" {
+		t.Errorf("expected taken copy to be intact, got %q", out)
+	}
+}
+
+func TestTakeBytesReset(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	s := AllocSlice[byte](a, 4)
+	a.TakeBytes(s)
+	a.Reset()
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "use after reset") {
+			t.Errorf("expected use after reset panic, got %q", msg)
+		}
+	}()
+	s.Get()
+}
+
+func TestTakeBytesDifferentArena(t *testing.T) {
+	a, b := New(), New()
+	defer a.Free()
+	defer b.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic taking a slice from another arena")
+		}
+	}()
+	b.TakeBytes(AllocSlice[byte](a, 4))
+}
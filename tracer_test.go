@@ -0,0 +1,65 @@
+package safearena
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewWithTracer(t *testing.T) {
+	var reports []string
+	orig := traceReport
+	traceReport = func(report string) { reports = append(reports, report) }
+	t.Cleanup(func() { traceReport = orig })
+
+	type header struct{ A, B int64 }
+
+	a := NewWithTracer()
+	Alloc(a, int32(1))
+	Alloc(a, header{})
+	AllocSlice[int64](a, 100)
+	a.Reset()
+	a.AllocBytes(3)
+
+	report := a.Report()
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a summary and 4 allocations, got:\n%s", report)
+	}
+	if !strings.HasSuffix(lines[0], ": 4 allocations, 823 bytes") {
+		t.Errorf("unexpected summary %q", lines[0])
+	}
+	for i, want := range []string{"800 B  [100]int64", "16 B  safearena.header", "4 B  int32", "3 B  [3]uint8"} {
+		if line := lines[i+1]; !strings.Contains(line, want) || !strings.Contains(line, "tracer_test.go") {
+			t.Errorf("expected line %d to contain %q and the allocation site, got %q", i+1, want, line)
+		}
+	}
+
+	a.Free()
+	if len(reports) != 1 || reports[0] != report {
+		t.Errorf("expected Free to print the report once, got %q", reports)
+	}
+}
+
+func TestNewWithTracerFreeAsync(t *testing.T) {
+	var reports []string
+	orig := traceReport
+	traceReport = func(report string) { reports = append(reports, report) }
+	t.Cleanup(func() { traceReport = orig })
+
+	a := NewWithTracer()
+	Alloc(a, int64(1))
+	report := a.Report()
+	a.FreeAsync()
+	if len(reports) != 1 || reports[0] != report {
+		t.Errorf("expected FreeAsync to print the report once, got %q", reports)
+	}
+}
+
+func TestReportUntraced(t *testing.T) {
+	a := New()
+	defer a.Free()
+	Alloc(a, 1)
+	if report := a.Report(); report != "" {
+		t.Errorf("expected no report from an untraced arena, got %q", report)
+	}
+}
@@ -0,0 +1,40 @@
+package safearena
+
+// OnViolation, if non-nil, is consulted before Ptr.Get, Ptr.Deref, or
+// Slice.Get panics on a stale access (use after free, reset, release, or
+// TakeBytes). It receives the error that would have been panicked with, and
+// returns true to let the program continue. Typically it logs the error:
+//
+//	safearena.OnViolation = func(err safearena.ArenaError) bool {
+//	    log.Printf("arena violation (continuing): %v", &err)
+//	    return true
+//	}
+//
+// When tolerated, Get does not return the stale pointer: freed arena memory
+// is unmapped and faults on access, which would crash the process outright.
+// Ptr.Get returns a pointer to a new zero value on the heap instead, and
+// Slice.Get a new zeroed heap slice of the same length and capacity, so the
+// caller degrades to working on zero values.
+//
+// **This trades correctness for availability. The access is still a bug:
+// the code reads zero values where it expected its data, and writes through
+// the returned pointer or slice are silently lost. Only enable it in
+// deployments that prefer degraded results to a crash, and treat every
+// logged violation as a defect to fix.**
+//
+// Only Ptr.Get, Ptr.Deref, and Slice.Get consult OnViolation. Every other
+// stale access always panics, including Ptr.Set, Slice.At, Slice.SetAt,
+// Slice.Len, Slice.Slice, Slice.Data, Slice.Copy, and the methods of the
+// container types (List, ArenaMap, Grid, and so on), as do allocation after
+// Free and double Free.
+//
+// When nil or when it returns false, the access panics as usual. Like the
+// other hooks, set it during initialization; it runs synchronously on the
+// offending goroutine and must be safe for concurrent use.
+var OnViolation func(err ArenaError) bool
+
+// tolerate reports whether OnViolation lets a stale access described by err
+// continue instead of panicking
+func tolerate(err *ArenaError) bool {
+	return OnViolation != nil && OnViolation(*err)
+}
@@ -0,0 +1,85 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func setOnViolation(t *testing.T, fn func(ArenaError) bool) {
+	t.Helper()
+	OnViolation = fn
+	t.Cleanup(func() { OnViolation = nil })
+}
+
+func TestOnViolationContinue(t *testing.T) {
+	var logged []ErrorKind
+	setOnViolation(t, func(err ArenaError) bool {
+		logged = append(logged, err.Kind)
+		return true
+	})
+
+	a := New()
+	p := Alloc(a, 42)
+	s := AllocSlice[int](a, 3)
+	a.Free()
+
+	if v := p.Deref(); v != 0 {
+		t.Errorf("expected zero value from tolerated Deref, got %d", v)
+	}
+	*p.Get() = 7 // Lands in a throwaway heap value
+	if got := s.Get(); len(got) != 3 || got[0] != 0 {
+		t.Errorf("expected zeroed slice of length 3, got %v", got)
+	}
+	if len(logged) != 3 || logged[0] != UseAfterFree {
+		t.Errorf("expected 3 UseAfterFree violations, got %v", logged)
+	}
+}
+
+func TestOnViolationPanic(t *testing.T) {
+	for name, fn := range map[string]func(ArenaError) bool{
+		"nil":           nil,
+		"returns false": func(ArenaError) bool { return false },
+	} {
+		t.Run(name, func(t *testing.T) {
+			setOnViolation(t, fn)
+			a := New()
+			p := Alloc(a, 42)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			p.Get()
+		})
+	}
+}
+
+func TestOnViolationNotConsulted(t *testing.T) {
+	setOnViolation(t, func(ArenaError) bool { return true })
+
+	for name, use := range map[string]func(p Ptr[int], s Slice[int]){
+		"Ptr.Set":     func(p Ptr[int], s Slice[int]) { p.Set(1) },
+		"Slice.At":    func(p Ptr[int], s Slice[int]) { s.At(0) },
+		"Slice.Len":   func(p Ptr[int], s Slice[int]) { s.Len() },
+		"Slice.Slice": func(p Ptr[int], s Slice[int]) { s.Slice(0, 1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := New()
+			p := Alloc(a, 42)
+			s := AllocSlice[int](a, 3)
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic despite OnViolation, got %q", msg)
+				}
+			}()
+			use(p, s)
+		})
+	}
+}
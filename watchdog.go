@@ -0,0 +1,103 @@
+package safearena
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxAgeWarning prints the warning of an arena that outlived its max age.
+// Tests replace it to capture the message.
+var maxAgeWarning = func(msg string) {
+	fmt.Print(msg)
+}
+
+// NewWithMaxAge creates an arena that warns if it is still not freed d after
+// creation. Arenas are meant to live for one request or task; one that stays
+// open, for example because it was accidentally stored in a long-lived
+// struct, holds its memory until the process exits. The warning names the
+// arena, where it was created, and its current Stats, which is usually enough
+// to find the leak in staging.
+//
+// The watchdog only warns: freeing an arena that may still be in use would be
+// unsafe. Free, TryFree, and FreeAsync stop the timer, so a freed arena never
+// warns and leaves no goroutine or timer behind. Reset does not restart it.
+//
+// Example:
+//
+//	a := safearena.NewWithMaxAge(30 * time.Second)
+//	defer a.Free()
+func NewWithMaxAge(d time.Duration) *Arena {
+	a := New()
+	site := captureStack(2)
+	a.watchdog = time.AfterFunc(d, func() {
+		if a.freed.Load() {
+			return // Free raced with the timer
+		}
+		stats := a.Stats()
+		maxAgeWarning(fmt.Sprintf("WARNING: %s not freed %v after creation at %s (%d allocations, %d bytes)\n",
+			arenaLabel(a.id, a.name), d, siteString(site), stats.Allocations, stats.Bytes))
+	})
+	return a
+}
+
+// States of a NewWithTTL arena; ttlNone for all other arenas
+const (
+	ttlNone    int32 = iota
+	ttlArmed         // Timer pending
+	ttlExpired       // Timer fired and freed, or is freeing, the arena
+	ttlFreed         // Freed by its owner before the timer fired
+)
+
+// NewWithTTL creates an arena that frees itself d after creation unless it
+// has been freed sooner, for ephemeral caches and other data without a clean
+// scope boundary. Free, TryFree, and FreeAsync cancel the timer.
+//
+// The timer and the owner race for the same free, and exactly one of them
+// wins: if the timer fires first, a later Free (or FreeAsync, or a
+// CloseFunc call) is a no-op instead of a double free, and TryFree reports
+// false. Freeing twice by hand still panics.
+//
+// Expiry frees the arena from the timer's goroutine, so, as with
+// NewConcurrent, every use of the arena (allocating from it as well as
+// reading its values) must hold AcquireRead or TryAcquireRead; expiry waits
+// for holders to release. Use without it races with expiry and can read or
+// allocate freed memory.
+//
+// Example:
+//
+//	a := safearena.NewWithTTL(time.Minute)
+//	// ... later, on each lookup ...
+//	release, ok := a.TryAcquireRead()
+//	if !ok { // Expired
+//	    a = safearena.NewWithTTL(time.Minute)
+//	    release = a.AcquireRead()
+//	    cache = safearena.Alloc(a, buildCache())
+//	}
+//	defer release()
+//	use(cache.Get())
+func NewWithTTL(d time.Duration) *Arena {
+	a := New()
+	a.readers = new(sync.RWMutex)
+	a.ttl.Store(ttlArmed)
+	a.watchdog = time.AfterFunc(d, func() {
+		if a.ttl.CompareAndSwap(ttlArmed, ttlExpired) {
+			a.tryFree(2, (*backing).Free)
+		}
+	})
+	return a
+}
+
+// claimFree marks a NewWithTTL arena as freed by its owner, so that its
+// timer no longer frees it. It is a no-op for other arenas.
+func (a *Arena) claimFree() {
+	if a.ttl.Load() == ttlArmed {
+		a.ttl.CompareAndSwap(ttlArmed, ttlFreed)
+	}
+}
+
+// expired reports whether a NewWithTTL arena's timer has fired, so a manual
+// free that finds the arena already freed is not a double free
+func (a *Arena) expired() bool {
+	return a.ttl.Load() == ttlExpired
+}
@@ -0,0 +1,135 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// captureMaxAgeWarnings redirects max-age warnings to the returned channel
+// for the duration of a test
+func captureMaxAgeWarnings(t *testing.T) <-chan string {
+	t.Helper()
+	warnings := make(chan string, 1)
+	orig := maxAgeWarning
+	maxAgeWarning = func(msg string) { warnings <- msg }
+	t.Cleanup(func() { maxAgeWarning = orig })
+	return warnings
+}
+
+func TestNewWithMaxAge(t *testing.T) {
+	warnings := captureMaxAgeWarnings(t)
+
+	a := NewWithMaxAge(10 * time.Millisecond)
+	defer a.Free()
+	Alloc(a, int64(1))
+
+	select {
+	case msg := <-warnings:
+		for _, want := range []string{"not freed", "watchdog_test.go", "1 allocations, 8 bytes"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("expected warning to contain %q, got %q", want, msg)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a max-age warning")
+	}
+}
+
+func TestNewWithMaxAgeFreed(t *testing.T) {
+	warnings := captureMaxAgeWarnings(t)
+
+	a := NewWithMaxAge(10 * time.Millisecond)
+	a.Free()
+	if a.watchdog.Stop() {
+		t.Error("expected Free to have stopped the timer")
+	}
+
+	select {
+	case msg := <-warnings:
+		t.Errorf("expected no warning after Free, got %q", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNewWithTTLExpires(t *testing.T) {
+	freed := make(chan struct{})
+	a := NewWithTTL(10 * time.Millisecond)
+	a.OnFree(func() error {
+		close(freed)
+		return nil
+	})
+	release := a.AcquireRead()
+	p := Alloc(a, 1)
+	time.Sleep(20 * time.Millisecond)
+	if p.Deref() != 1 {
+		t.Error("expected expiry to wait for the reader")
+	}
+	release()
+
+	select {
+	case <-freed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the TTL to free the arena")
+	}
+	if p.Valid() {
+		t.Error("expected values to be invalid after expiry")
+	}
+	if _, ok := a.TryAcquireRead(); ok {
+		t.Error("expected TryAcquireRead to fail after expiry")
+	}
+
+	// The owner's cleanup after expiry is not a double free
+	a.Free()
+	a.Free()
+	a.FreeAsync()
+	if err := a.CloseFunc()(); err != nil {
+		t.Errorf("expected CloseFunc after expiry to succeed, got %v", err)
+	}
+	if a.TryFree() {
+		t.Error("expected TryFree after expiry to report false")
+	}
+}
+
+func TestNewWithTTLFreedFirst(t *testing.T) {
+	a := NewWithTTL(10 * time.Millisecond)
+	a.Free()
+	if a.watchdog.Stop() {
+		t.Error("expected Free to have stopped the timer")
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// The timer did not claim the arena, so freeing by hand again is still
+	// caught
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "double free") {
+			t.Errorf("expected double free panic, got %q", msg)
+		}
+	}()
+	a.Free()
+}
+
+func TestNewWithTTLRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var frees atomic.Int32
+		a := NewWithTTL(time.Duration(i%3) * time.Microsecond)
+		a.OnFree(func() error {
+			frees.Add(1)
+			return nil
+		})
+		time.Sleep(time.Duration(i%5) * time.Microsecond)
+		a.Free() // Never a double free, whichever side wins
+
+		deadline := time.Now().Add(time.Second)
+		for frees.Load() == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(time.Millisecond)
+		if n := frees.Load(); n != 1 {
+			t.Fatalf("iteration %d: expected exactly one free, got %d", i, n)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package safearena
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// ArenaWriter is an io.Writer that accumulates bytes in arena memory.
+// The buffer grows from the arena as needed, so formatted or encoded output
+// (fmt.Fprintf, json.Encoder, io.Copy, ...) never touches the heap.
+//
+// An ArenaWriter is not safe for concurrent use.
+type ArenaWriter struct {
+	arena *Arena
+	gen   uint64 // Arena generation the buffer belongs to
+	buf   []byte
+}
+
+// NewWriter returns an ArenaWriter with an initial capacity of capacity bytes.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	w := a.NewWriter(256)
+//	fmt.Fprintf(w, "id=%d", 42)
+//	json.NewEncoder(w).Encode(payload)
+//	out := w.Bytes() // Slice[byte] backed by the arena
+func (a *Arena) NewWriter(capacity int) *ArenaWriter {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	return &ArenaWriter{
+		arena: a,
+		gen:   a.gen.Load(),
+		buf:   a.makeBytes(0, capacity),
+	}
+}
+
+// Write appends p to the buffer, growing it from the arena when needed.
+// It always returns len(p) and a nil error.
+//
+// Panics if the arena has been freed.
+func (w *ArenaWriter) Write(p []byte) (int, error) {
+	w.check()
+	w.grow(len(p))
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer without converting it to a byte slice.
+//
+// Panics if the arena has been freed.
+func (w *ArenaWriter) WriteString(s string) (int, error) {
+	w.check()
+	w.grow(len(s))
+	w.buf = append(w.buf, s...)
+	return len(s), nil
+}
+
+// Bytes returns the accumulated content as an arena slice.
+// Later writes may reallocate the buffer, so the returned Slice reflects the
+// content at the time of the call.
+//
+// Panics if the arena has been freed.
+func (w *ArenaWriter) Bytes() Slice[byte] {
+	w.check()
+	return Slice[byte]{
+		slice: w.buf,
+		arena: w.arena,
+		gen:   w.gen,
+	}
+}
+
+// String returns the accumulated content as a heap string.
+//
+// Panics if the arena has been freed.
+func (w *ArenaWriter) String() string {
+	w.check()
+	return string(w.buf)
+}
+
+// Len returns the number of bytes written
+func (w *ArenaWriter) Len() int {
+	return len(w.buf)
+}
+
+// check panics if the writer's arena has been freed or reset
+func (w *ArenaWriter) check() {
+	if w.arena.freed.Load() || !w.arena.live(w.gen) {
+		stack := captureStack(3)
+		panic(w.arena.staleError(w.gen, stack, nil))
+	}
+}
+
+// grow ensures room for n more bytes, reallocating from the arena if needed
+func (w *ArenaWriter) grow(n int) {
+	w.buf = growBytes(w.arena, w.buf, n)
+}
+
+// growBytes returns buf with room for n more bytes. If buf is full it is
+// copied into a new arena array of at least double the capacity.
+func growBytes(a *Arena, buf []byte, n int) []byte {
+	if len(buf)+n <= cap(buf) {
+		return buf
+	}
+
+	newCap := max(2*cap(buf), len(buf)+n)
+	grown := a.makeBytes(len(buf), newCap)
+	copy(grown, buf)
+	return grown
+}
+
+// Sprintf formats according to a format specifier and returns the result as
+// a string whose bytes live in the arena, so per-request log lines and small
+// JSON fragments produce no heap garbage.
+//
+// The returned string is only valid until the arena is freed, reset, or
+// released past its mark. Unlike Ptr and Slice it carries no lifetime check:
+// reading it afterwards does not panic but silently observes reused or
+// poisoned memory. Never store it anywhere that outlives the arena; copy it
+// with strings.Clone (or use SprintfHeap) when it must.
+//
+// Panics if the arena has already been freed.
+//
+// Example:
+//
+//	line := a.Sprintf("%s %s %d", r.Method, r.URL.Path, status)
+//	log.Print(line)             // OK: used before Free
+//	keep := strings.Clone(line) // Copy to the heap to keep it
+func (a *Arena) Sprintf(format string, args ...any) string {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+
+	// fmt writes the fully formatted output in a single Write call, so
+	// sizing the buffer from the format string usually avoids any growth.
+	w := &ArenaWriter{
+		arena: a,
+		gen:   a.gen.Load(),
+		buf:   a.makeBytes(0, len(format)+16*len(args)),
+	}
+	fmt.Fprintf(w, format, args...)
+	return unsafe.String(unsafe.SliceData(w.buf), len(w.buf))
+}
+
+// SprintfHeap is like Sprintf but returns a heap copy of the result, which
+// stays valid after the arena is freed. The formatting scratch space still
+// comes from the arena.
+//
+// Panics if the arena has already been freed.
+func (a *Arena) SprintfHeap(format string, args ...any) string {
+	if a.freed.Load() {
+		stack := captureStack(2)
+		panic(errorWithHint(a, "allocation after free", stack, AllocAfterFree))
+	}
+	return strings.Clone(a.Sprintf(format, args...))
+}
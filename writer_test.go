@@ -0,0 +1,145 @@
+package safearena
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestArenaWriterIOCopy(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	src := strings.Repeat("payload-", 1000)
+	w := a.NewWriter(16) // Force several grows
+
+	n, err := io.Copy(w, strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(src)) {
+		t.Errorf("expected %d bytes copied, got %d", len(src), n)
+	}
+	if w.String() != src {
+		t.Error("expected writer content to match source")
+	}
+	if got := string(w.Bytes().Get()); got != src {
+		t.Error("expected Bytes to match source")
+	}
+}
+
+func TestArenaWriterFormatting(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	w := a.NewWriter(0)
+	fmt.Fprintf(w, "id=%d ", 42)
+	w.WriteString("name=")
+	if err := json.NewEncoder(w).Encode("test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id=42 name=\"test\"\n"
+	if w.String() != want {
+		t.Errorf("expected %q, got %q", want, w.String())
+	}
+	if w.Len() != len(want) {
+		t.Errorf("expected length %d, got %d", len(want), w.Len())
+	}
+}
+
+func TestArenaWriterAfterFree(t *testing.T) {
+	tests := []struct {
+		name string
+		use  func(w *ArenaWriter)
+	}{
+		{"write", func(w *ArenaWriter) { w.Write([]byte("x")) }},
+		{"write string", func(w *ArenaWriter) { w.WriteString("x") }},
+		{"bytes", func(w *ArenaWriter) { w.Bytes() }},
+		{"string", func(w *ArenaWriter) { _ = w.String() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New()
+			w := a.NewWriter(8)
+			w.WriteString("data")
+			a.Free()
+
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "use after free") {
+					t.Errorf("expected use after free panic, got %q", msg)
+				}
+			}()
+			tt.use(w)
+		})
+	}
+}
+
+func TestArenaWriterBytesAfterFree(t *testing.T) {
+	a := New()
+	w := a.NewWriter(8)
+	w.WriteString("data")
+	out := w.Bytes()
+	a.Free()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic accessing writer bytes after free")
+		}
+	}()
+	_ = out.Get()
+}
+
+func TestArenaSprintf(t *testing.T) {
+	a := New()
+	defer a.Free()
+
+	got := a.Sprintf("id=%d name=%q", 42, "test")
+	if got != `id=42 name="test"` {
+		t.Errorf("expected formatted string, got %q", got)
+	}
+
+	long := a.Sprintf("%s", strings.Repeat("x", 1000)) // Outgrows the initial buffer
+	if len(long) != 1000 {
+		t.Errorf("expected length 1000, got %d", len(long))
+	}
+}
+
+func TestArenaSprintfLifetime(t *testing.T) {
+	a := New()
+	arenaStr := a.Sprintf("request %d", 7)
+	heapStr := a.SprintfHeap("request %d", 7)
+	kept := strings.Clone(arenaStr)
+	a.Free()
+
+	if heapStr != "request 7" {
+		t.Errorf("expected SprintfHeap result to survive Free, got %q", heapStr)
+	}
+	if kept != "request 7" {
+		t.Errorf("expected cloned string to survive Free, got %q", kept)
+	}
+	// arenaStr must not be read here: its bytes belong to the freed arena.
+}
+
+func TestArenaSprintfAfterFree(t *testing.T) {
+	for _, fn := range []func(a *Arena){
+		func(a *Arena) { a.Sprintf("x") },
+		func(a *Arena) { a.SprintfHeap("x") },
+	} {
+		a := New()
+		a.Free()
+		func() {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "allocation after free") {
+					t.Errorf("expected allocation after free panic, got %q", msg)
+				}
+			}()
+			fn(a)
+		}()
+	}
+}